@@ -2,15 +2,18 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/acaloiaro/frm"
 	"github.com/acaloiaro/frm/routers/frmchi"
+	"github.com/acaloiaro/frm/types"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/httplog/v2"
 )
@@ -81,6 +84,16 @@ func main() {
 			log.Println("GOT SUBMISSION!", submission)
 			return
 		},
+		Validators: map[string]frm.Validator{
+			"Valid customer ID": func(ctx context.Context, field types.FormField, value []string) (err error) {
+				for _, v := range value {
+					if !strings.HasPrefix(v, "CUST-") {
+						return errors.New("Please enter a valid customer ID, e.g. CUST-1234")
+					}
+				}
+				return
+			},
+		},
 	})
 	if err != nil {
 		panic(err)
@@ -0,0 +1 @@
+ALTER TABLE short_codes DROP COLUMN IF EXISTS data;
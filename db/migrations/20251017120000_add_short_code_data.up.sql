@@ -0,0 +1,3 @@
+ALTER TABLE short_codes ADD COLUMN IF NOT EXISTS data JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+COMMENT ON COLUMN short_codes.data IS 'data attached to the short code, from which form fields may be prefilled, see types.ShortCodeData';
@@ -111,7 +111,8 @@ WHERE workspace_id = @workspace_id
 
 -- name: SaveShortCode :one
 
-INSERT INTO short_codes (workspace_id, form_id, subject_id, short_code)
-VALUES (@workspace_id, @form_id, @subject_id, @short_code) ON CONFLICT (subject_id, form_id) DO
+INSERT INTO short_codes (workspace_id, form_id, subject_id, short_code, data)
+VALUES (@workspace_id, @form_id, @subject_id, @short_code, COALESCE(sqlc.narg(data)::jsonb, '{}'::jsonb)) ON CONFLICT (subject_id, form_id) DO
 UPDATE
-SET updated_at = timezone('utc', now()) RETURNING *;
+SET updated_at = timezone('utc', now()),
+    data = COALESCE(sqlc.narg(data)::jsonb, short_codes.data) RETURNING *;
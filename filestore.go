@@ -0,0 +1,96 @@
+package frm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// DefaultFileStoreDir is the directory where the default [LocalFileStore] stores uploaded files
+const DefaultFileStoreDir = "frm_uploads"
+
+var ErrInvalidFileKey = errors.New("file keys must be relative paths that do not leave the file store")
+
+// FileStore stores files uploaded to the collector
+//
+// Keys are generated by frm, and are stored in form submissions as part of [types.FileReference]. Hosts resolve file
+// references to file contents by calling Get with the reference's key.
+type FileStore interface {
+	// Put stores the contents of a file under key
+	Put(ctx context.Context, key string, contents io.Reader) (err error)
+	// Get retrieves the contents of the file stored under key. Callers must close contents.
+	Get(ctx context.Context, key string) (contents io.ReadCloser, err error)
+	// Delete removes the file stored under key
+	Delete(ctx context.Context, key string) (err error)
+}
+
+// LocalFileStore is a FileStore that stores files on local disk, in Dir
+type LocalFileStore struct {
+	Dir string // directory in which files are stored
+}
+
+// NewLocalFileStore initializes a LocalFileStore that stores files in dir
+func NewLocalFileStore(dir string) *LocalFileStore {
+	return &LocalFileStore{Dir: dir}
+}
+
+// Put stores the contents of a file under key
+func (l *LocalFileStore) Put(ctx context.Context, key string, contents io.Reader) (err error) {
+	path, err := l.path(key)
+	if err != nil {
+		return
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0o750)
+	if err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o640)
+	if err != nil {
+		return
+	}
+
+	// files are only stored once their contents are flushed by Close
+	_, err = io.Copy(f, contents)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return fmt.Errorf("unable to store file: %w", err)
+	}
+	return
+}
+
+// Get retrieves the contents of the file stored under key
+func (l *LocalFileStore) Get(ctx context.Context, key string) (contents io.ReadCloser, err error) {
+	path, err := l.path(key)
+	if err != nil {
+		return
+	}
+	return os.Open(path)
+}
+
+// Delete removes the file stored under key
+func (l *LocalFileStore) Delete(ctx context.Context, key string) (err error) {
+	path, err := l.path(key)
+	if err != nil {
+		return
+	}
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		err = nil
+	}
+	return
+}
+
+// path returns the path on disk for the file stored under key
+func (l *LocalFileStore) path(key string) (path string, err error) {
+	key = filepath.FromSlash(key)
+	if !filepath.IsLocal(key) {
+		return "", ErrInvalidFileKey
+	}
+	return filepath.Join(l.Dir, key), nil
+}
@@ -2,13 +2,17 @@ package frm
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/acaloiaro/frm/internal"
+	"github.com/acaloiaro/frm/types"
 )
 
 const (
@@ -27,7 +31,9 @@ type Frm struct {
 	CollectorFooter     string                 // footer shown at the bottom of the collector page
 	DraftMaxAge         time.Duration          // the duration that form drafts may remain in the draft stage before removal
 	DBArgs              internal.DBArgs        // database arguments
+	FileStore           FileStore              // storage for files uploaded to the collector
 	Receiver            FormSubmissionReceiver // function that processes incoming form submissions
+	Validators          map[string]Validator   // custom validators that may be attached to fields, keyed by name
 	WorkspaceID         string                 // ID of the workspace that frm acts on behalf of
 	WorkspaceIDUrlParam string                 // name of the URL parameter that provides your workspace ID
 }
@@ -38,10 +44,12 @@ type Args struct {
 	CollectorMountPoint string                 // path on the router to mount frm's collector
 	CollectorFooter     string                 // footer shown at the bottom of the collector page
 	DraftMaxAge         time.Duration          // the duration that form drafts may remain in the draft state before removal
+	FileStore           FileStore              // storage for files uploaded to the collector, files are stored on local disk in [DefaultFileStoreDir] by default
 	PostgresDisableSSL  bool                   // disable ssl when connecting to postgres
 	PostgresSchema      string                 // postgres schema where frm stores data
 	PostgresURL         string                 // postgres database URL
 	Reciever            FormSubmissionReceiver // function that processes incoming form submissions
+	Validators          map[string]Validator   // custom validators that builders may attach to fields, keyed by the name shown in the builder, e.g. "Valid customer ID"
 	WorkspaceID         string                 // ID of the workspace for which frm is being initialized
 	WorkspaceIDUrlParam string                 // named URL parameter that identifies the workspace, e.g. for route /{workspace_id}, the value would be "workspace_id"
 }
@@ -49,6 +57,13 @@ type Args struct {
 // FormSubmissionReceiver processes form submissions
 type FormSubmissionReceiver = func(ctx context.Context, submission FormSubmission) (err error)
 
+// Validator validates values submitted to fields that builders have attached it to, e.g. checking that a customer ID
+// exists in the host's database
+//
+// Validators run after frm's built-in validation succeeds, and only for fields with non-blank values. Returned errors
+// are shown to respondents as the field's validation error.
+type Validator = func(ctx context.Context, field types.FormField, value []string) (err error)
+
 // FormStatus is the status of a Form
 //
 // - Published forms are available to be used
@@ -79,10 +94,15 @@ func New(args Args) (f *Frm, err error) {
 			DisableSSL: args.PostgresDisableSSL,
 			Schema:     args.PostgresSchema,
 		},
+		FileStore:           args.FileStore,
 		Receiver:            args.Reciever,
+		Validators:          args.Validators,
 		WorkspaceID:         args.WorkspaceID,
 		WorkspaceIDUrlParam: args.WorkspaceIDUrlParam,
 	}
+	if f.FileStore == nil {
+		f.FileStore = NewLocalFileStore(DefaultFileStoreDir)
+	}
 	return
 }
 
@@ -200,6 +220,11 @@ func (f *Frm) ListForms(ctx context.Context, args ListFormsArgs) (forms []Form,
 	return
 }
 
+// ValidatorNames returns the names of the instance's custom validators, in alphabetical order
+func (f *Frm) ValidatorNames() []string {
+	return slices.Sorted(maps.Keys(f.Validators))
+}
+
 // Instance returns the frm instance from the request context (if available)
 func Instance(ctx context.Context) (i *Frm, err error) {
 	var ok bool
@@ -213,16 +238,28 @@ func Instance(ctx context.Context) (i *Frm, err error) {
 type CreateShortCodeArgs struct {
 	FormID    int64
 	SubjectID string
+	Data      types.ShortCodeData // data from which fields are prefilled when the form is submitted via the short code, or nil to keep existing short codes' data
 }
 
 // CreateShortCode creates short code for a given form and subject
+//
+// Creating a short code for a form and subject that already have one returns the existing short code, with its data
+// replaced by args.Data. The existing short code's data is kept when args.Data is nil.
 func (f *Frm) CreateShortCode(ctx context.Context, args CreateShortCodeArgs) (sc ShortCode, err error) {
+	var data []byte
+	if args.Data != nil {
+		data, err = json.Marshal(args.Data)
+		if err != nil {
+			return
+		}
+	}
 	var s internal.ShortCode
 	s, err = internal.Q(ctx, f.DBArgs).SaveShortCode(ctx, internal.SaveShortCodeParams{
 		WorkspaceID: f.WorkspaceID,
 		FormID:      &args.FormID,
 		ShortCode:   internal.GenShortCode(),
 		SubjectID:   args.SubjectID,
+		Data:        data,
 	})
 	return (ShortCode)(s), err
 }
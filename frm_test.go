@@ -2,8 +2,12 @@ package frm_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/acaloiaro/frm"
@@ -64,6 +68,62 @@ func TestCreateShortCode(t *testing.T) {
 	}
 }
 
+func TestCreateShortCodeKeepsData(t *testing.T) {
+	ctx := context.Background()
+	f, err := frm.New(frm.Args{
+		PostgresURL:         os.Getenv("POSTGRES_URL"),
+		PostgresDisableSSL:  true,
+		WorkspaceID:         "1",
+		WorkspaceIDUrlParam: "client_id",
+		PostgresSchema:      "frm_test",
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	draft, err := internal.Q(ctx, internal.DBArgs{
+		URL:        os.Getenv("POSTGRES_URL"),
+		DisableSSL: true,
+		Schema:     "frm_test",
+	}).SaveForm(ctx, internal.SaveFormParams{
+		Name:        "hello world",
+		Fields:      types.FormFields{},
+		WorkspaceID: "1",
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	data := types.ShortCodeData{"account": "42"}
+	_, err = f.CreateShortCode(ctx, frm.CreateShortCodeArgs{FormID: draft.ID, SubjectID: "prefilled_subject", Data: data})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// short codes created again without data keep their data
+	sc, err := f.CreateShortCode(ctx, frm.CreateShortCodeArgs{FormID: draft.ID, SubjectID: "prefilled_subject"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !maps.Equal(sc.Data, data) {
+		t.Errorf("expected short code data %v to be kept, got: %v", data, sc.Data)
+	}
+
+	// short codes created again with data have their data replaced
+	replaced := types.ShortCodeData{"account": "43"}
+	sc, err = f.CreateShortCode(ctx, frm.CreateShortCodeArgs{FormID: draft.ID, SubjectID: "prefilled_subject", Data: replaced})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !maps.Equal(sc.Data, replaced) {
+		t.Errorf("expected short code data to be replaced by %v, got: %v", replaced, sc.Data)
+	}
+}
+
 func TestCopyForm(t *testing.T) {
 	copiedFormNameSuffix := "(COPY)"
 	ctx := context.Background()
@@ -129,3 +189,40 @@ func TestNoopWhenPoolUnavailable(t *testing.T) {
 		t.Error(fmt.Errorf("expected: '%s' but got: '%s'", internal.ErrNoopDatabase, err))
 	}
 }
+
+func TestLocalFileStore(t *testing.T) {
+	ctx := context.Background()
+	store := frm.NewLocalFileStore(t.TempDir())
+	const key = "1/field/file.txt"
+	err := store.Put(ctx, key, strings.NewReader("hello world"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contents, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(contents)
+	contents.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "hello world" {
+		t.Errorf("expected: 'hello world' but got: '%s'", b)
+	}
+
+	err = store.Delete(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = store.Get(ctx, key)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected deleted file not to exist, got: %v", err)
+	}
+
+	err = store.Put(ctx, "../outside.txt", strings.NewReader("hello world"))
+	if err != frm.ErrInvalidFileKey {
+		t.Errorf("expected: '%s' but got: '%v'", frm.ErrInvalidFileKey, err)
+	}
+}
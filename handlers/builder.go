@@ -1,12 +1,17 @@
 package handlers
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"math"
 	"net/http"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/acaloiaro/frm"
@@ -20,6 +25,9 @@ import (
 	"github.com/jackc/pgx/v5"
 )
 
+// bytesPerMegabyte converts file sizes configured in the builder, in megabytes, to bytes
+const bytesPerMegabyte = 1 << 20
+
 var ErrFormIDNotFound = errors.New("a form ID was not found in the request context")
 var ErrFieldIDNotFound = errors.New("a field ID was not found in the request context")
 
@@ -196,7 +204,19 @@ func LogicConfiguratorChoices(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = builder.LogicConfiguratorStepThree((frm.Form)(draft), draft.Fields[fieldID.String()], targetField).Render(ctx, w)
+	// the URL param 'key' is the key of the condition whose target was chosen
+	key := r.Form.Get("key")
+	if key == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// the URL param 'comparator' is the condition's comparator, when it has been chosen
+	condition := types.FieldLogicCondition{TargetFieldID: targetFieldID}
+	if comparator, err := types.FieldLogicComparatorString(r.Form.Get("comparator")); err == nil && slices.Contains(targetField.LogicComparators(), comparator) {
+		condition.Comparator = comparator
+	}
+	err = builder.LogicConfiguratorStepThree((frm.Form)(draft), draft.Fields[fieldID.String()], key, targetField, condition).Render(ctx, w)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 	}
@@ -204,6 +224,48 @@ func LogicConfiguratorChoices(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(200)
 }
 
+// OptionsParentValues renders the configuration of the answers to a field's options parent for which each of the
+// field's options is offered
+func OptionsParentValues(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	f, err := frm.Instance(ctx)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	formID, err := formID(ctx, f)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	fieldID, err := fieldID(ctx)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	draft, err := internal.Q(ctx, f.DBArgs).GetDraft(ctx, internal.GetDraftParams{
+		WorkspaceID: f.WorkspaceID,
+		ID:          *formID,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	field, ok := draft.Fields[fieldID.String()]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	err = builder.OptionsParentValues((frm.Form)(draft), field).Render(ctx, w)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
 // UpdateSettings handles updates to form settings
 func UpdateSettings(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -339,6 +401,64 @@ func NewField(w http.ResponseWriter, r *http.Request) {
 		newField.Label = "New single choice field"
 	case types.FormFieldTypeSingleChoiceSpaced:
 		newField.Label = "New single choice field (spaced)"
+	case types.FormFieldTypeEmail:
+		newField.Label = "New email field"
+		newField.Placeholder = "name@example.com"
+	case types.FormFieldTypeNumber:
+		newField.Label = "New number field"
+		newField.Placeholder = "Enter a number"
+		newField.DataType = types.FormFieldDataTypeNumeric
+	case types.FormFieldTypeDate:
+		newField.Label = "New date field"
+	case types.FormFieldTypeTime:
+		newField.Label = "New time field"
+	case types.FormFieldTypeDateTime:
+		newField.Label = "New date and time field"
+	case types.FormFieldTypeFile:
+		newField.Label = "New file upload field"
+	case types.FormFieldTypeNPS:
+		newField.Label = "How likely are you to recommend us to a friend or colleague?"
+		newField.OptionLabels = []string{"Not at all likely", "Extremely likely"}
+		newField.DataType = types.FormFieldDataTypeRating
+	case types.FormFieldTypeStarRating:
+		newField.Label = "New star rating field"
+		newField.DataType = types.FormFieldDataTypeRating
+	case types.FormFieldTypeCheckboxGroup:
+		newField.Label = "New checkboxes field"
+	case types.FormFieldTypeConsent:
+		newField.Label = "I accept the [terms of service](https://example.com/terms)"
+	case types.FormFieldTypeMatrix:
+		newField.Label = "New matrix field"
+		newField.Options = toFormFieldOption(nil, []string{"Strongly disagree", "Disagree", "Neutral", "Agree", "Strongly agree"})
+		newField.Rows = toFormFieldOption(nil, []string{"Statement 1", "Statement 2"})
+		newField.DataType = types.FormFieldDataTypeRating
+	case types.FormFieldTypeHeading:
+		newField.Label = "New section"
+	case types.FormFieldTypeParagraph:
+		newField.Label = "Paragraph"
+		newField.Content = "Write instructions for respondents here. **Markdown** is supported."
+	case types.FormFieldTypeDivider:
+		newField.Label = "Divider"
+	case types.FormFieldTypeImage:
+		newField.Label = "Image"
+	case types.FormFieldTypeRanking:
+		newField.Label = "New ranking field"
+	case types.FormFieldTypePhone:
+		newField.Label = "New phone field"
+		newField.Placeholder = "Phone number"
+		newField.DefaultCountry = "US"
+	case types.FormFieldTypeSignature:
+		newField.Label = "Signature"
+	case types.FormFieldTypeCalculated:
+		newField.Label = "New calculated field"
+		newField.Expression = "0"
+		newField.DataType = types.FormFieldDataTypeNumeric
+	case types.FormFieldTypeAddress:
+		newField.Label = "New address field"
+	case types.FormFieldTypeSlider:
+		newField.Label = "New slider field"
+		newField.OptionLabels = []string{"Not at all", "Completely"}
+		newField.DataType = types.FormFieldDataTypeNumeric
 	}
 
 	fields[fieldID.String()] = *newField
@@ -408,6 +528,13 @@ func UpdateFields(w http.ResponseWriter, r *http.Request) {
 
 	// newFields becomes the draft's full set of fields after this endpoint succeeds
 	newFields := map[string]*types.FormField{}
+	// crossFieldRules are the cross-field rules of each field, keyed by field ID and the rules' keys
+	crossFieldRules := map[string]map[string]*types.CrossFieldRule{}
+	// logicGroups are the logic groups of each field, keyed by field ID and the groups' keys
+	logicGroups := map[string]map[string]*logicGroup{}
+	// parentValues are the answers to fields' options parents for which their options are offered, keyed by field ID
+	// and option ID
+	parentValues := map[string]map[string][]string{}
 
 	// iterate over all submitted fields, adding each one to 'newFields'
 	for formFieldName, formFieldValues := range r.Form {
@@ -449,7 +576,35 @@ func UpdateFields(w http.ResponseWriter, r *http.Request) {
 		case fieldName == "placeholder":
 			field.Placeholder = fieldValues[0]
 		case fieldName == "options":
-			field.Options = toFormFieldOption(draft.Fields[fieldID], fieldValues)
+			field.Options = toFormFieldOption(draft.Fields[fieldID].Options, fieldValues)
+		case fieldName == "options_parent_id":
+			if parentID, err := uuid.Parse(fieldValues[0]); err == nil {
+				field.OptionsParentID = &parentID
+			}
+		case fieldGroup == builder.FieldGroupOptionsParent:
+			if _, ok := parentValues[fieldID]; !ok {
+				parentValues[fieldID] = map[string][]string{}
+			}
+			parentValues[fieldID][fieldName] = fieldValues
+		case fieldName == "content":
+			field.Content = fieldValues[0]
+		case fieldName == "expression":
+			field.Expression = strings.TrimSpace(fieldValues[0])
+		case fieldName == "prefill":
+			field.Prefill, err = types.PrefillSourceString(fieldValues[0])
+			if err != nil {
+				field.Prefill = types.PrefillSourceNone
+			}
+		case fieldName == "prefill_key":
+			field.PrefillKey = strings.TrimSpace(fieldValues[0])
+		case fieldName == "default_country":
+			field.DefaultCountry = fieldValues[0]
+		case fieldName == "image_url":
+			field.ImageURL = strings.TrimSpace(fieldValues[0])
+		case fieldName == "rows":
+			field.Rows = toFormFieldOption(draft.Fields[fieldID].Rows, fieldValues)
+		case fieldName == "required_parts":
+			field.RequiredParts = fieldValues
 		case fieldName == "option_labels":
 			field.OptionLabels = fieldValues
 		case fieldName == "option_ordering":
@@ -457,6 +612,40 @@ func UpdateFields(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				field.OptionOrder = types.OptionOrderNatural
 			}
+		case fieldName == "min":
+			field.Min = parseOptionalNumber(fieldValues[0])
+		case fieldName == "max":
+			field.Max = parseOptionalNumber(fieldValues[0])
+		case fieldName == "step":
+			field.Step = parseOptionalNumber(fieldValues[0])
+		case fieldName == "earliest":
+			field.Earliest = strings.TrimSpace(fieldValues[0])
+		case fieldName == "latest":
+			field.Latest = strings.TrimSpace(fieldValues[0])
+		case fieldName == "min_choices":
+			field.MinChoices, _ = strconv.Atoi(strings.TrimSpace(fieldValues[0]))
+		case fieldName == "max_choices":
+			field.MaxChoices, _ = strconv.Atoi(strings.TrimSpace(fieldValues[0]))
+		case fieldName == "min_length":
+			field.MinLength, _ = strconv.Atoi(strings.TrimSpace(fieldValues[0]))
+		case fieldName == "max_length":
+			field.MaxLength, _ = strconv.Atoi(strings.TrimSpace(fieldValues[0]))
+		case fieldName == "pattern":
+			field.Pattern = strings.TrimSpace(fieldValues[0])
+		case fieldName == "validation_message":
+			field.ValidationMessage = strings.TrimSpace(fieldValues[0])
+		case fieldName == "validators":
+			field.Validators = slices.DeleteFunc(fieldValues, func(v string) bool { return strings.TrimSpace(v) == "" })
+		case fieldName == "mime_types":
+			field.MimeTypes = slices.DeleteFunc(fieldValues, func(v string) bool { return strings.TrimSpace(v) == "" })
+		case fieldName == "max_file_size":
+			if size := parseOptionalNumber(fieldValues[0]); size != nil && *size > 0 {
+				field.MaxFileSize = int64(*size * bytesPerMegabyte)
+			}
+		case fieldGroup == builder.FieldGroupSettings && fieldName == "integer_only":
+			field.IntegerOnly = slices.Contains(fieldValues, "on")
+		case fieldGroup == builder.FieldGroupSettings && fieldName == "toggle":
+			field.Toggle = slices.Contains(fieldValues, "on")
 		case fieldName == "data_type":
 			dt, err := types.FormFieldDataTypeString(fieldValues[0])
 			if err != nil {
@@ -470,18 +659,40 @@ func UpdateFields(w http.ResponseWriter, r *http.Request) {
 		case fieldGroup == builder.FieldGroupSettings && fieldName == "hidden":
 			hidden := (len(fieldValues) > 1 && fieldValues[1] == "on") || (len(fieldValues) > 0 && fieldValues[0] == "on")
 			field.Hidden = hidden
-		case fieldGroup == builder.FieldGroupLogic && fieldName == builder.FieldLogicTargetFieldID:
-			targetFieldID, err := uuid.Parse(fieldValues[0])
-			if err != nil {
+		case fieldGroup == builder.FieldGroupCrossFieldRules:
+			key, attribute, ok := strings.Cut(fieldName, "_")
+			if !ok {
 				continue
 			}
-			field.Logic.TargetFieldID = targetFieldID
-		// field logic, subject field chosen
-		case fieldGroup == builder.FieldGroupLogic && fieldName == builder.FieldLogicTargetFieldValue:
-			field.Logic.TriggerValues = fieldValues
-		// field logic, comparator chosen
-		case fieldGroup == builder.FieldGroupLogic && fieldName == builder.FieldLogicComparator:
-			field.Logic.TriggerComparator, _ = types.FieldLogicComparatorString(fieldValues[0])
+			if _, ok := crossFieldRules[fieldID]; !ok {
+				crossFieldRules[fieldID] = map[string]*types.CrossFieldRule{}
+			}
+			rule, ok := crossFieldRules[fieldID][key]
+			if !ok {
+				rule = &types.CrossFieldRule{}
+				crossFieldRules[fieldID][key] = rule
+			}
+			switch attribute {
+			case "kind":
+				rule.Kind, _ = types.CrossFieldRuleKindString(fieldValues[0])
+			case "field_ids":
+				for _, v := range fieldValues {
+					if id, err := uuid.Parse(v); err == nil {
+						rule.FieldIDs = append(rule.FieldIDs, id)
+					}
+				}
+			case "message":
+				rule.Message = strings.TrimSpace(fieldValues[0])
+			}
+		// field logic, operator combining groups chosen
+		case fieldGroup == builder.FieldGroupLogic && fieldName == builder.FieldLogicOperator:
+			field.Logic.Operator, _ = types.FieldLogicOperatorString(fieldValues[0])
+		// field logic, value set by logic
+		case fieldGroup == builder.FieldGroupLogic && fieldName == builder.FieldLogicValue:
+			field.Logic.Value = fieldValues[0]
+		// field logic, message shown when logic ends the form
+		case fieldGroup == builder.FieldGroupLogic && fieldName == builder.FieldLogicEndMessage:
+			field.Logic.EndMessage = strings.TrimSpace(fieldValues[0])
 		// field logic, actions to take
 		case fieldGroup == builder.FieldGroupLogic && fieldName == builder.FieldLogicActions:
 			if len(fieldValues) > 0 {
@@ -495,6 +706,85 @@ func UpdateFields(w http.ResponseWriter, r *http.Request) {
 				}
 				field.Logic.TriggerActions = actions
 			}
+		// field logic, group operators and conditions
+		case fieldGroup == builder.FieldGroupLogic:
+			key, attribute, ok := strings.Cut(fieldName, "_")
+			if !ok {
+				continue
+			}
+			if _, ok := logicGroups[fieldID]; !ok {
+				logicGroups[fieldID] = map[string]*logicGroup{}
+			}
+			group, ok := logicGroups[fieldID][key]
+			if !ok {
+				group = &logicGroup{conditions: map[string]*types.FieldLogicCondition{}}
+				logicGroups[fieldID][key] = group
+			}
+			if attribute == builder.FieldLogicOperator {
+				group.operator, _ = types.FieldLogicOperatorString(fieldValues[0])
+				continue
+			}
+			key, attribute, ok = strings.Cut(attribute, "_")
+			if !ok {
+				continue
+			}
+			condition, ok := group.conditions[key]
+			if !ok {
+				condition = &types.FieldLogicCondition{}
+				group.conditions[key] = condition
+			}
+			switch attribute {
+			case builder.FieldLogicTargetFieldID:
+				condition.TargetFieldID, _ = uuid.Parse(fieldValues[0])
+			case builder.FieldLogicComparator:
+				condition.Comparator, _ = types.FieldLogicComparatorString(fieldValues[0])
+			case builder.FieldLogicTargetFieldValue:
+				condition.Values = fieldValues
+			}
+		}
+	}
+
+	for fieldID, rules := range crossFieldRules {
+		field, ok := newFields[fieldID]
+		if !ok {
+			continue
+		}
+		for _, key := range sortedKeys(rules) {
+			field.CrossFieldRules = append(field.CrossFieldRules, *rules[key])
+		}
+	}
+
+	// options are offered for answers to their field's current options parent, so answers to previous parents are
+	// discarded
+	for fieldID, field := range newFields {
+		var parent *types.FormField
+		if field.HasOptionsParent() {
+			parent = newFields[field.OptionsParentID.String()]
+		}
+		for i, option := range field.Options {
+			field.Options[i].ParentValues = nil
+			if parent == nil {
+				continue
+			}
+			for _, value := range parentValues[fieldID][option.ID.String()] {
+				if slices.ContainsFunc(parent.Options, func(o types.Option) bool { return o.Value == value }) {
+					field.Options[i].ParentValues = append(field.Options[i].ParentValues, value)
+				}
+			}
+		}
+	}
+
+	for fieldID, groups := range logicGroups {
+		field, ok := newFields[fieldID]
+		if !ok {
+			continue
+		}
+		for _, key := range sortedKeys(groups) {
+			group := types.FieldLogicGroup{Operator: groups[key].operator}
+			for _, conditionKey := range sortedKeys(groups[key].conditions) {
+				group.Conditions = append(group.Conditions, *groups[key].conditions[conditionKey])
+			}
+			field.Logic.Groups = append(field.Logic.Groups, group)
 		}
 	}
 
@@ -554,6 +844,13 @@ func DeleteField(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
+	if problems := draft.Fields.DeleteProblems(fmt.Sprint(fieldID)); len(problems) > 0 {
+		// the delete button's own content is not replaced by the fields that depend on the field
+		w.Header().Set("HX-Retarget", "#messages")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		builder.DeleteFieldProblems(draft.Fields[fmt.Sprint(fieldID)], draft.Fields, problems).Render(ctx, w)
+		return
+	}
 	updatedFields := draft.Fields
 	delete(updatedFields, fmt.Sprint(fieldID))
 	draft.Fields = updatedFields
@@ -720,6 +1017,29 @@ func PublishDraft(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	draft, err := internal.Q(ctx, f.DBArgs).GetDraft(ctx, internal.GetDraftParams{
+		WorkspaceID: f.WorkspaceID,
+		ID:          *draftID,
+	})
+	if err != nil {
+		slog.Error("unable to publish draft", "error", err)
+		ui.Toast(ui.ToastArgs{
+			Position: ui.ToastPositionTop,
+			Type:     ui.ToastTypeError,
+			Message:  "Failed! Your form was not saved.",
+		}).Render(ctx, w)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// drafts with errors are not published, and their problems are shown to the builder in place of the usual toast
+	problems := draft.Fields.Lint()
+	if problems.HasErrors() {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		builder.PublishProblems(problems).Render(ctx, w)
+		return
+	}
+
 	tx, err := internal.Tx(ctx, f.DBArgs)
 	if err != nil {
 		slog.Error("unable to publish draft", "error", err)
@@ -779,6 +1099,9 @@ func PublishDraft(w http.ResponseWriter, r *http.Request) {
 		Type:     ui.ToastTypeSuccess,
 		Message:  "Success! Your form has been saved.",
 	}).Render(ctx, w)
+	if len(problems) > 0 {
+		builder.PublishProblems(problems).Render(ctx, w)
+	}
 }
 
 // DeleteForm deletes forms
@@ -849,10 +1172,36 @@ func fieldID(ctx context.Context) (fieldID *uuid.UUID, err error) {
 // Example: [2ad1591d-c852-47b5-a16d-0b90892421c8][logic]target_field_id
 var formFieldIDExtractor = regexp.MustCompile(`^\[([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-4[a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})\](\[(.+)\]){0,1}(.+){1}?$`)
 
+// logicGroup accumulates the operator and conditions of a logic group, keyed by the conditions' keys, as they're parsed
+// from the builder's inputs
+type logicGroup struct {
+	operator   types.FieldLogicOperator
+	conditions map[string]*types.FieldLogicCondition
+}
+
+// sortedKeys sorts the keys of cross-field rules, logic groups and logic conditions configured in the builder. They're
+// keyed by their position, or the time they were added in the builder, and keep that order.
+func sortedKeys[T any](m map[string]T) []string {
+	return slices.SortedFunc(maps.Keys(m), func(a, b string) int {
+		x, _ := strconv.ParseInt(a, 10, 64)
+		y, _ := strconv.ParseInt(b, 10, 64)
+		return cmp.Compare(x, y)
+	})
+}
+
+// parseOptionalNumber parses numbers from numeric field settings, returning nil when no valid number is provided
+func parseOptionalNumber(value string) *float64 {
+	n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return nil
+	}
+	return &n
+}
+
 // toFormFieldOption takes a list of options as strings and determines whether the string options represent new options
 // being created, in which case an ID/value must be generated for the option, or if the option is amongst the existing
 // options for the field being updated.
-func toFormFieldOption(field types.FormField, options []string) types.FieldOptions {
+func toFormFieldOption(existing types.FieldOptions, options []string) types.FieldOptions {
 	fieldOptions := types.FieldOptions{}
 	for i, option := range options {
 		var id uuid.UUID
@@ -866,7 +1215,7 @@ func toFormFieldOption(field types.FormField, options []string) types.FieldOptio
 				Order: i,
 			})
 		} else {
-			for _, opt := range field.Options {
+			for _, opt := range existing {
 				opt.Order = i
 				if opt.ID == optionID {
 					fieldOptions = append(fieldOptions, opt)
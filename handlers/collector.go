@@ -1,12 +1,21 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"maps"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"path/filepath"
+	"regexp"
 	"slices"
+	"strings"
 
 	"github.com/acaloiaro/frm"
 	"github.com/acaloiaro/frm/internal"
@@ -17,6 +26,17 @@ import (
 	"github.com/jackc/pgx/v5"
 )
 
+// maxUploadMemory is the maximum number of bytes of uploaded files held in memory while parsing submissions. The
+// remainder is stored in temporary files.
+const maxUploadMemory = 32 << 20
+
+// maxSubmissionOverhead is the number of bytes that submissions may contain in addition to uploaded files, e.g. the
+// values of other fields and drawn signatures
+const maxSubmissionOverhead = 10 << 20
+
+// compositePartExtractor extracts field IDs and part names from the names of composite fields' parts, e.g. matrix rows
+var compositePartExtractor = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-4[a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})\[(.+)\]$`)
+
 // ShortCode handles requsts for form short codes and renders the corresponding form
 //
 // When Forms are submitted via short URL, submissions are attributed to the subject with which the short code was
@@ -51,7 +71,7 @@ func ShortCode(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	// Render the form collector
-	err = collector.Viewer(collector.ViewerArgs{Form: (frm.Form)(f), ShortCode: *shortCode}).Render(ctx, w)
+	err = collector.Viewer(collector.ViewerArgs{Form: (frm.Form)(f), ShortCode: *shortCode, Query: r.URL.Query()}).Render(ctx, w)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 	}
@@ -78,26 +98,28 @@ func Collect(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
-	err = r.ParseForm()
-	if err != nil {
+	if limit, ok := maxSubmissionSize(f); ok {
+		r.Body = http.MaxBytesReader(w, r.Body, limit)
+	}
+	err = r.ParseMultipartForm(maxUploadMemory)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		slog.Info("[collector] submission is too large", "limit", maxBytesErr.Limit)
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil && !errors.Is(err, http.ErrNotMultipart) {
 		slog.Error("[collector] unable to parse form", "error", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 	submission := r.Form
-	errs := validate(f, submission)
-	if errs.Any() {
-		slog.Debug("[collector] failed validation", "errors", errs)
-		w.WriteHeader(http.StatusBadRequest)
-		err = ui.ValidationErrors(errs).Render(r.Context(), w)
-		if err != nil {
-			slog.Error("[collector] unable to render validation error response", "errors", errs)
-			w.WriteHeader(http.StatusInternalServerError)
-		} else {
-			w.WriteHeader(http.StatusBadRequest)
-
+	uploads := uploadedFiles(f, r.MultipartForm)
+	// file names stand in for the values of file fields, so that file fields are validated like all other fields
+	for fieldID, fileHeaders := range uploads {
+		for _, fh := range fileHeaders {
+			submission.Add(fieldID, fh.Filename)
 		}
-		return
 	}
 	sc := submission.Get("short_code")
 	submission.Del("short_code")
@@ -113,6 +135,35 @@ func Collect(w http.ResponseWriter, r *http.Request) {
 	} else if errors.Is(err, pgx.ErrNoRows) {
 		slog.Info("[collector] short code not found", "params", arg)
 	}
+	prefill(f, submission, shortCode.Data)
+	parts := compositeParts(f, submission)
+	states := f.Fields.EvaluateLogic(submission)
+	exclude(states, submission, uploads, parts)
+	enforce(f, states, submission, uploads, parts)
+	calculations := calculate(f, submission)
+	maps.DeleteFunc(calculations, func(fieldID string, _ types.FormFieldSubmission) bool { return states[fieldID].Excluded })
+	errs := validate(ctx, f, submission, i.Validators, states)
+	maps.Copy(errs, validateFiles(f, uploads))
+	maps.Copy(errs, validateParts(f, parts, states))
+	for fieldID, err := range validateCrossField(f, submission, states) {
+		// fields' own errors are more specific than errors relating them to other fields
+		if _, ok := errs[fieldID]; !ok {
+			errs[fieldID] = err
+		}
+	}
+	if errs.Any() {
+		slog.Debug("[collector] failed validation", "errors", errs)
+		w.WriteHeader(http.StatusBadRequest)
+		err = ui.ValidationErrors(errs).Render(r.Context(), w)
+		if err != nil {
+			slog.Error("[collector] unable to render validation error response", "errors", errs)
+			w.WriteHeader(http.StatusInternalServerError)
+		} else {
+			w.WriteHeader(http.StatusBadRequest)
+
+		}
+		return
+	}
 
 	// TODO: Keep track of the submission id
 	// submissionID := r.Form.Get("id")
@@ -130,19 +181,36 @@ func Collect(w http.ResponseWriter, r *http.Request) {
 		// TODO do something with the submission id
 		submission.Del("submission_id")
 	}
+	fileRefs, err := storeFiles(ctx, i.FileStore, f, uploads)
+	if err != nil {
+		slog.Error("[collector] unable to store uploaded files", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	signatureRefs, err := storeSignatures(ctx, i.FileStore, *formID, signatures(f, submission))
+	if err != nil {
+		slog.Error("[collector] unable to store signatures", "error", err)
+		deleteFiles(ctx, i.FileStore, fileRefs)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	maps.Copy(fileRefs, signatureRefs)
 	formFieldValues := types.FormFieldValues{}
 	for fieldID, fieldValue := range submission {
-		formFieldValues[fieldID] = types.FormFieldSubmission{
-			ID:          uuid.New(),
-			FormFieldID: uuid.MustParse(fieldID), // TODO do not use MustParse
-			Order:       f.Fields[fieldID].Order,
-			Required:    f.Fields[fieldID].Required,
-			Hidden:      f.Fields[fieldID].Hidden,
-			Type:        f.Fields[fieldID].Type,
-			DataType:    f.Fields[fieldID].DataType,
-			Value:       fieldValue,
+		if f.Fields[fieldID].IsContent() {
+			continue
+		}
+		formFieldValues[fieldID] = fieldSubmission(f.Fields[fieldID], f.Fields[fieldID].SubmissionValue(fieldValue))
+		if ref, ok := fileRefs[fieldID]; ok {
+			ffv := formFieldValues[fieldID]
+			ffv.Value = ref
+			formFieldValues[fieldID] = ffv
 		}
 	}
+	for fieldID, fieldParts := range parts {
+		formFieldValues[fieldID] = fieldSubmission(f.Fields[fieldID], f.Fields[fieldID].PartsSubmissionValue(fieldParts))
+	}
+	maps.Copy(formFieldValues, calculations)
 	var s internal.FormSubmission
 	s, err = internal.Q(ctx, i.DBArgs).SaveSubmission(ctx, internal.SaveSubmissionParams{
 		// ID:          submissionID, TODO save submission id
@@ -154,6 +222,7 @@ func Collect(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		slog.Error("[collector] unable to save submission", "error", err)
+		deleteFiles(ctx, i.FileStore, fileRefs)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -170,15 +239,417 @@ func Collect(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// prefill sets the values of fields prefilled from short code data
+//
+// Values of fields prefilled from the collector URL's query string are submitted by the collector. Values submitted for
+// fields prefilled from short code data are discarded, so that respondents cannot alter them.
+func prefill(f internal.Form, submission url.Values, data types.ShortCodeData) {
+	for fieldID, field := range f.Fields {
+		if !field.IsPrefilled() || field.Prefill != types.PrefillSourceShortCode {
+			continue
+		}
+		submission.Del(fieldID)
+		if value, ok := field.PrefillValue(nil, data); ok {
+			submission.Set(fieldID, value)
+		}
+	}
+}
+
+// calculate computes the values of calculated fields from the values submitted to the fields they reference
+//
+// Calculated fields' values are computed authoritatively by the server, so values submitted for calculated fields are
+// discarded. Calculations that cannot be computed from the submitted values, e.g. because they divide by zero, are
+// logged and stored without a value, rather than failing the respondent's submission.
+func calculate(f internal.Form, submission url.Values) (calculations types.FormFieldValues) {
+	calculations = types.FormFieldValues{}
+	for fieldID, field := range f.Fields {
+		if field.Type == types.FormFieldTypeCalculated {
+			submission.Del(fieldID)
+		}
+	}
+	for fieldID, field := range f.Fields {
+		if field.Type != types.FormFieldTypeCalculated {
+			continue
+		}
+		var value any
+		result, err := field.Calculate(f.Fields, submission)
+		if err != nil {
+			slog.Warn("[collector] unable to calculate field value", "field_id", fieldID, "expression", field.Expression, "error", err)
+		} else {
+			value = result
+		}
+		calculations[fieldID] = fieldSubmission(field, value)
+	}
+	return
+}
+
+// fieldSubmission is the submission of value to field
+func fieldSubmission(field types.FormField, value any) types.FormFieldSubmission {
+	return types.FormFieldSubmission{
+		ID:          uuid.New(),
+		FormFieldID: field.ID,
+		Order:       field.Order,
+		Required:    field.Required,
+		Hidden:      field.Hidden,
+		Type:        field.Type,
+		DataType:    field.DataType,
+		Value:       value,
+	}
+}
+
+// compositeParts removes the parts of composite fields from submissions, and returns them grouped by field ID
+//
+// Composite fields' parts are submitted as "<field id>[<part>]", e.g. the rows of matrix fields.
+func compositeParts(f internal.Form, submission url.Values) (parts map[string]url.Values) {
+	parts = map[string]url.Values{}
+	for name, values := range submission {
+		matches := compositePartExtractor.FindStringSubmatch(name)
+		if len(matches) < 3 {
+			continue
+		}
+		field, ok := f.Fields[matches[1]]
+		if !ok || !field.IsComposite() {
+			continue
+		}
+		if _, ok := parts[field.ID.String()]; !ok {
+			parts[field.ID.String()] = url.Values{}
+		}
+		parts[field.ID.String()][matches[2]] = values
+		submission.Del(name)
+	}
+	return
+}
+
+// validateParts validates the parts of composite fields, including composite fields for which no parts were submitted
+func validateParts(f internal.Form, parts map[string]url.Values, states map[string]types.FieldState) (errs types.ValidationErrors) {
+	errs = types.ValidationErrors{}
+	for fieldID, field := range f.Fields {
+		if !field.IsComposite() || states[fieldID].Excluded {
+			continue
+		}
+		if err := field.WithState(states[fieldID]).ValidateParts(parts[fieldID]); err != nil {
+			errs[fieldID] = err
+		}
+	}
+	return
+}
+
+// exclude removes the values of fields hidden by their logic from submissions, so that they're neither validated nor
+// saved
+func exclude(states map[string]types.FieldState, submission url.Values, uploads map[string][]*multipart.FileHeader, parts map[string]url.Values) {
+	for fieldID, state := range states {
+		if !state.Excluded {
+			continue
+		}
+		submission.Del(fieldID)
+		delete(uploads, fieldID)
+		delete(parts, fieldID)
+	}
+}
+
+// enforce applies the actions of fields' logic to submissions
+//
+// Values set by logic fill fields that respondents left blank, and replace the values of read-only fields. Values
+// submitted for read-only fields are otherwise discarded, unless they're prefilled. Fields' states are updated to
+// reflect the enforced answers to their options parents.
+func enforce(f internal.Form, states map[string]types.FieldState, submission url.Values, uploads map[string][]*multipart.FileHeader, parts map[string]url.Values) {
+	for fieldID, state := range states {
+		if state.Excluded {
+			continue
+		}
+		answered := slices.ContainsFunc(submission[fieldID], func(v string) bool { return strings.TrimSpace(v) != "" })
+		switch {
+		case state.Value != nil && (state.Disabled || !answered):
+			submission[fieldID] = state.Value
+		case state.Disabled && !f.Fields[fieldID].IsPrefilled():
+			submission.Del(fieldID)
+			delete(uploads, fieldID)
+			delete(parts, fieldID)
+		}
+	}
+
+	// the options offered by fields depend on the answers to their options parents, which logic may have changed
+	for fieldID, state := range states {
+		parent, ok := f.Fields[fieldID].OptionsParent(f.Fields)
+		if !ok || state.Excluded || states[parent.ID.String()].Excluded {
+			continue
+		}
+		state.Parent = submission[parent.ID.String()]
+		states[fieldID] = state
+	}
+}
+
+// validateCrossField validates the rules relating fields' values to other fields' values, e.g. "confirm email equals
+// email"
+//
+// Fields excluded by logic are not shown to respondents, so rules relating fields to excluded fields are skipped.
+func validateCrossField(f internal.Form, submission url.Values, states map[string]types.FieldState) (errs types.ValidationErrors) {
+	errs = types.ValidationErrors{}
+	shown := maps.Clone(f.Fields)
+	maps.DeleteFunc(shown, func(fieldID string, _ types.FormField) bool { return states[fieldID].Excluded })
+	for fieldID, field := range shown {
+		if err := field.ValidateCrossField(shown, submission); err != nil {
+			errs[fieldID] = err
+		}
+	}
+	return
+}
+
+// uploadedFiles returns the files uploaded to a form's file fields, keyed by field ID
+//
+// Empty file inputs are submitted as files without names, and are excluded.
+func uploadedFiles(f internal.Form, form *multipart.Form) (uploads map[string][]*multipart.FileHeader) {
+	uploads = map[string][]*multipart.FileHeader{}
+	if form == nil {
+		return
+	}
+	for fieldID, fileHeaders := range form.File {
+		field, ok := f.Fields[fieldID]
+		if !ok || field.Type != types.FormFieldTypeFile {
+			continue
+		}
+		for _, fh := range fileHeaders {
+			if fh.Filename == "" {
+				continue
+			}
+			uploads[fieldID] = append(uploads[fieldID], fh)
+		}
+	}
+	return
+}
+
+// maxSubmissionSize is the maximum size in bytes of submissions to a form, which allows every file field to be
+// uploaded a file of the largest size that the form's file fields accept
+//
+// ok is false when submissions' size is not limited, because one of the form's file fields accepts files of any size.
+func maxSubmissionSize(f internal.Form) (size int64, ok bool) {
+	var files, largest int64
+	for _, field := range f.Fields {
+		if field.Type != types.FormFieldTypeFile {
+			continue
+		}
+		if field.MaxFileSize <= 0 {
+			return 0, false
+		}
+		files++
+		largest = max(largest, field.MaxFileSize)
+	}
+	return files*largest + maxSubmissionOverhead, true
+}
+
+// validateFiles validates files uploaded to file fields, which accept a single file
+func validateFiles(f internal.Form, uploads map[string][]*multipart.FileHeader) (errs types.ValidationErrors) {
+	errs = types.ValidationErrors{}
+	for fieldID, fileHeaders := range uploads {
+		field := f.Fields[fieldID]
+		if len(fileHeaders) > 1 {
+			errs[fieldID] = types.ErrTooManyFiles
+			continue
+		}
+		for _, fh := range fileHeaders {
+			contentType, err := detectContentType(field, fh)
+			if err != nil {
+				slog.Error("[collector] unable to read uploaded file", "error", err)
+				errs[fieldID] = types.ErrFileTypeNotAllowed
+				break
+			}
+			if err := field.ValidateFile(contentType, fh.Size); err != nil {
+				errs[fieldID] = err
+				break
+			}
+		}
+	}
+	return
+}
+
+// genericContentTypes are the content types detected for the contents of many specific types of files, e.g. CSV files
+// are plain text, and docx files are zip archives
+var genericContentTypes = []string{"application/octet-stream", "application/zip", "text/plain", "text/xml"}
+
+// detectContentType detects the content type of files uploaded to a field from their contents, rather than trusting
+// the content type reported by the respondent's browser
+//
+// Detection is inconclusive when it finds a generic content type that the field does not accept. The content type of
+// the file's extension, or the reported content type, is then used instead, preferring a type the field accepts.
+func detectContentType(field types.FormField, fh *multipart.FileHeader) (contentType string, err error) {
+	file, err := fh.Open()
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return
+	}
+	contentType = http.DetectContentType(head[:n])
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if !slices.Contains(genericContentTypes, mediaType) || field.AcceptsContentType(contentType) {
+		return contentType, nil
+	}
+	var candidates []string
+	for _, candidate := range []string{mime.TypeByExtension(strings.ToLower(filepath.Ext(fh.Filename))), fh.Header.Get("Content-Type")} {
+		if candidate == "" {
+			continue
+		}
+		if field.AcceptsContentType(candidate) {
+			return candidate, nil
+		}
+		candidates = append(candidates, candidate)
+	}
+	if len(candidates) > 0 {
+		return candidates[0], nil
+	}
+	return contentType, nil
+}
+
+// storeFiles stores uploaded files in the file store, returning references to the stored files keyed by field ID
+func storeFiles(ctx context.Context, store frm.FileStore, f internal.Form, uploads map[string][]*multipart.FileHeader) (refs map[string]types.FileReference, err error) {
+	refs = map[string]types.FileReference{}
+	for fieldID, fileHeaders := range uploads {
+		// file fields accept a single file, and submissions with more are rejected by validateFiles
+		fh := fileHeaders[0]
+		var contentType string
+		contentType, err = detectContentType(f.Fields[fieldID], fh)
+		if err != nil {
+			deleteFiles(ctx, store, refs)
+			return nil, err
+		}
+		ref := types.FileReference{
+			Key:         fmt.Sprintf("%d/%s/%s%s", f.ID, fieldID, uuid.New(), strings.ToLower(filepath.Ext(fh.Filename))),
+			Name:        filepath.Base(fh.Filename),
+			ContentType: contentType,
+			Size:        fh.Size,
+		}
+		err = storeFile(ctx, store, ref.Key, fh)
+		if err != nil {
+			deleteFiles(ctx, store, refs)
+			return nil, err
+		}
+		refs[fieldID] = ref
+	}
+	return
+}
+
+// signatures returns the PNG images drawn on a form's signature fields, keyed by field ID
+//
+// Signatures are submitted as PNG data URLs, and should be validated before they are decoded. Unsigned signature fields
+// are excluded.
+func signatures(f internal.Form, submission url.Values) (pngs map[string][]byte) {
+	pngs = map[string][]byte{}
+	for fieldID, field := range f.Fields {
+		if field.Type != types.FormFieldTypeSignature || strings.TrimSpace(submission.Get(fieldID)) == "" {
+			continue
+		}
+		png, err := types.DecodeSignature(submission.Get(fieldID))
+		if err != nil {
+			continue
+		}
+		pngs[fieldID] = png
+	}
+	return
+}
+
+// storeSignatures stores the PNG images drawn on signature fields in the file store, alongside uploaded files
+func storeSignatures(ctx context.Context, store frm.FileStore, formID int64, pngs map[string][]byte) (refs map[string]types.FileReference, err error) {
+	refs = map[string]types.FileReference{}
+	for fieldID, png := range pngs {
+		ref := types.FileReference{
+			Key:         fmt.Sprintf("%d/%s/%s.png", formID, fieldID, uuid.New()),
+			Name:        "signature.png",
+			ContentType: "image/png",
+			Size:        int64(len(png)),
+		}
+		err = store.Put(ctx, ref.Key, bytes.NewReader(png))
+		if err != nil {
+			deleteFiles(ctx, store, refs)
+			return nil, err
+		}
+		refs[fieldID] = ref
+	}
+	return
+}
+
+// storeFile stores a single uploaded file in the file store
+func storeFile(ctx context.Context, store frm.FileStore, key string, fh *multipart.FileHeader) (err error) {
+	file, err := fh.Open()
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	return store.Put(ctx, key, file)
+}
+
+// deleteFiles deletes stored files when the submission they belong to could not be saved
+func deleteFiles(ctx context.Context, store frm.FileStore, refs map[string]types.FileReference) {
+	for _, ref := range refs {
+		if err := store.Delete(ctx, ref.Key); err != nil {
+			slog.Error("[collector] unable to delete stored file", "key", ref.Key, "error", err)
+		}
+	}
+}
+
 // validate validates forms
-func validate(f internal.Form, submission url.Values) (errs types.ValidationErrors) {
+//
+// Invalid values of prefilled fields are logged and removed from the submission, rather than reported to respondents.
+func validate(ctx context.Context, f internal.Form, submission url.Values, validators map[string]frm.Validator, states map[string]types.FieldState) (errs types.ValidationErrors) {
 	errs = types.ValidationErrors{}
-	for submittedFieldID := range maps.Keys(submission) {
-		field := f.Fields[submittedFieldID]
-		formFieldValue := submission[submittedFieldID]
-		if err := field.Validate(formFieldValue); err != nil {
-			errs[submittedFieldID] = err
+	for fieldID, field := range f.Fields {
+		// content blocks accept no values, and the values of composite and calculated fields are validated separately
+		if field.IsContent() || field.IsComposite() || field.Type == types.FormFieldTypeCalculated {
+			continue
+		}
+		if states[fieldID].Excluded {
+			continue
+		}
+		field = field.WithState(states[fieldID])
+		// prefilled fields are not shown to respondents, who cannot provide values missing from their prefill sources
+		if field.IsPrefilled() {
+			field.Required = false
+		}
+		// fields may be absent from submissions, e.g. unchecked checkbox groups and unanswered radio buttons, and are
+		// only validated when they're required
+		formFieldValue, submitted := submission[fieldID]
+		if !submitted && !field.Required {
+			continue
 		}
+		err := field.ValidateWithParent(formFieldValue, states[fieldID].Parent)
+		if err == nil {
+			err = runValidators(ctx, field, formFieldValue, validators)
+		}
+		if err == nil {
+			continue
+		}
+		// respondents cannot correct the values of prefilled fields, so invalid prefilled values are discarded
+		if field.IsPrefilled() {
+			slog.Warn("[collector] discarding invalid prefilled value", "field_id", fieldID, "error", err)
+			submission.Del(fieldID)
+			continue
+		}
+		errs[fieldID] = err
 	}
 	return errs
 }
+
+// runValidators runs the host's custom validators attached to a field, returning the first validation error
+//
+// Validators only run for non-blank values, leaving unanswered fields to the field's Required setting. Validators that
+// are attached to fields, but no longer registered by the host, are skipped.
+func runValidators(ctx context.Context, field types.FormField, value []string, validators map[string]frm.Validator) (err error) {
+	if !slices.ContainsFunc(value, func(v string) bool { return strings.TrimSpace(v) != "" }) {
+		return nil
+	}
+	for _, name := range field.Validators {
+		validator, ok := validators[name]
+		if !ok {
+			slog.Warn("[collector] skipping unregistered validator", "validator", name, "field_id", field.ID)
+			continue
+		}
+		if err = validator(ctx, field, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/acaloiaro/frm"
+	"github.com/acaloiaro/frm/internal"
+	"github.com/acaloiaro/frm/types"
+	"github.com/google/uuid"
+)
+
+// upload returns the header of a file uploaded to a field, as the collector receives it
+func upload(t *testing.T, fieldID, name, contentType string, contents []byte) *multipart.FileHeader {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="`+fieldID+`"; filename="`+name+`"`)
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = part.Write(contents); err != nil {
+		t.Fatal(err)
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = form.RemoveAll() })
+	return form.File[fieldID][0]
+}
+
+func TestDetectContentType(t *testing.T) {
+	const docxType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	var docx bytes.Buffer
+	archive := zip.NewWriter(&docx)
+	if _, err := archive.Create("word/document.xml"); err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	tests := []struct {
+		name        string
+		mimeTypes   []string
+		filename    string
+		reported    string
+		contents    []byte
+		want        string
+		wantAllowed bool
+	}{
+		{name: "csv", mimeTypes: []string{"text/csv"}, filename: "people.csv", reported: "text/csv", contents: []byte("name,email\nAda,ada@example.com\n"), want: "text/csv", wantAllowed: true},
+		{name: "docx", mimeTypes: []string{docxType}, filename: "letter.docx", reported: docxType, contents: docx.Bytes(), want: docxType, wantAllowed: true},
+		{name: "plain text allowed", mimeTypes: []string{"text/plain"}, filename: "notes.txt", reported: "text/plain", contents: []byte("notes"), want: "text/plain", wantAllowed: true},
+		{name: "disguised image", mimeTypes: []string{"text/csv"}, filename: "people.csv", reported: "text/csv", contents: png, want: "image/png", wantAllowed: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			field := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeFile, MimeTypes: test.mimeTypes}
+			fh := upload(t, field.ID.String(), test.filename, test.reported, test.contents)
+			contentType, err := detectContentType(field, fh)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != test.want {
+				t.Errorf("expected content type %q, got: %q", test.want, contentType)
+			}
+			if err := field.ValidateFile(contentType, fh.Size); (err == nil) != test.wantAllowed {
+				t.Errorf("expected allowed: %t, got: %v", test.wantAllowed, err)
+			}
+		})
+	}
+}
+
+func TestValidateFilesRejectsMultipleFiles(t *testing.T) {
+	field := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeFile}
+	f := internal.Form{Fields: types.FormFields{field.ID.String(): field}}
+	uploads := map[string][]*multipart.FileHeader{field.ID.String(): {
+		upload(t, field.ID.String(), "first.txt", "text/plain", []byte("first")),
+		upload(t, field.ID.String(), "second.txt", "text/plain", []byte("second")),
+	}}
+	if err := validateFiles(f, uploads)[field.ID.String()]; !errors.Is(err, types.ErrTooManyFiles) {
+		t.Errorf("expected %v, got: %v", types.ErrTooManyFiles, err)
+	}
+	uploads[field.ID.String()] = uploads[field.ID.String()][:1]
+	if errs := validateFiles(f, uploads); errs.Any() {
+		t.Errorf("expected a single file to be valid, got: %v", errs)
+	}
+}
+
+func TestMaxSubmissionSize(t *testing.T) {
+	small := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeFile, MaxFileSize: 1 << 20}
+	large := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeFile, MaxFileSize: 5 << 20}
+	unlimited := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeFile}
+	text := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeTextSingle}
+
+	f := internal.Form{Fields: types.FormFields{small.ID.String(): small, large.ID.String(): large, text.ID.String(): text}}
+	if size, ok := maxSubmissionSize(f); !ok || size != 2*(5<<20)+maxSubmissionOverhead {
+		t.Errorf("expected submissions to be limited by the largest file size, got: %d, %t", size, ok)
+	}
+	f.Fields[unlimited.ID.String()] = unlimited
+	if _, ok := maxSubmissionSize(f); ok {
+		t.Error("expected submissions not to be limited when a file field accepts files of any size")
+	}
+}
+
+func TestValidateSkipsRequiredPrefilledFields(t *testing.T) {
+	account := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeTextSingle, Required: true, Prefill: types.PrefillSourceShortCode, PrefillKey: "account"}
+	name := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeTextSingle, Required: true}
+	f := internal.Form{Fields: types.FormFields{account.ID.String(): account, name.ID.String(): name}}
+	submission := url.Values{}
+	// the short code has no data for the prefilled field
+	prefill(f, submission, types.ShortCodeData{})
+
+	errs := validate(context.Background(), f, submission, nil, f.Fields.EvaluateLogic(submission))
+	if err, ok := errs[account.ID.String()]; ok {
+		t.Errorf("expected prefilled fields without values not to be required, got: %v", err)
+	}
+	if _, ok := errs[name.ID.String()]; !ok {
+		t.Error("expected fields shown to respondents to be required")
+	}
+}
+
+func TestValidateDiscardsInvalidPrefilledValues(t *testing.T) {
+	seats := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeNumber, Prefill: types.PrefillSourceShortCode, PrefillKey: "seats"}
+	f := internal.Form{Fields: types.FormFields{seats.ID.String(): seats}}
+	submission := url.Values{}
+	prefill(f, submission, types.ShortCodeData{"seats": "many"})
+
+	errs := validate(context.Background(), f, submission, nil, f.Fields.EvaluateLogic(submission))
+	if err, ok := errs[seats.ID.String()]; ok {
+		t.Errorf("expected invalid prefilled values not to be reported, got: %v", err)
+	}
+	if submission.Has(seats.ID.String()) {
+		t.Errorf("expected invalid prefilled values to be discarded, got: %v", submission[seats.ID.String()])
+	}
+}
+
+func TestUntouchedSliders(t *testing.T) {
+	optional := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeSlider}
+	required := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeSlider, Required: true}
+	f := internal.Form{Fields: types.FormFields{optional.ID.String(): optional, required.ID.String(): required}}
+	// sliders are not submitted until respondents move them
+	submission := url.Values{}
+
+	errs := validate(context.Background(), f, submission, nil, f.Fields.EvaluateLogic(submission))
+	if err, ok := errs[optional.ID.String()]; ok {
+		t.Errorf("expected untouched optional sliders to be valid, got: %v", err)
+	}
+	if err := errs[required.ID.String()]; !errors.Is(err, types.ErrRequiredNoValueProvided) {
+		t.Errorf("expected untouched required sliders to be unanswered, got: %v", err)
+	}
+	if value := optional.SubmissionValue(submission[optional.ID.String()]); value != nil {
+		t.Errorf("expected untouched sliders to store nothing, got: %v", value)
+	}
+}
+
+func TestValidateCrossFieldSkipsExcludedFields(t *testing.T) {
+	contact := types.FormField{ID: uuid.New(), Order: 0, Label: "Contact me", Type: types.FormFieldTypeSingleChoice, Options: types.FieldOptions{
+		{ID: uuid.New(), Value: "yes", Label: "Yes"},
+		{ID: uuid.New(), Value: "no", Label: "No"},
+	}}
+	email := types.FormField{ID: uuid.New(), Order: 1, Label: "Email", Type: types.FormFieldTypeEmail, Logic: &types.FieldLogic{
+		TargetFieldID:     contact.ID,
+		TriggerComparator: types.FieldLogicComparatorEqual,
+		TriggerValues:     []string{"yes"},
+		TriggerActions:    types.FieldLogicTriggerActions{types.FieldLogicTriggerShow},
+	}}
+	confirm := types.FormField{ID: uuid.New(), Order: 2, Label: "Confirm email", Type: types.FormFieldTypeEmail, CrossFieldRules: []types.CrossFieldRule{
+		{Kind: types.CrossFieldRuleKindEqual, FieldIDs: []uuid.UUID{email.ID}},
+	}}
+	f := internal.Form{Fields: types.FormFields{contact.ID.String(): contact, email.ID.String(): email, confirm.ID.String(): confirm}}
+
+	// the email is hidden, and its stale value is excluded
+	submission := url.Values{contact.ID.String(): {"no"}, email.ID.String(): {"old@example.com"}, confirm.ID.String(): {"new@example.com"}}
+	if errs := validateCrossField(f, submission, f.Fields.EvaluateLogic(submission)); len(errs) > 0 {
+		t.Errorf("expected rules relating fields to excluded fields to be skipped, got: %v", errs)
+	}
+
+	submission.Set(contact.ID.String(), "yes")
+	if errs := validateCrossField(f, submission, f.Fields.EvaluateLogic(submission)); !errors.Is(errs[confirm.ID.String()], types.ErrValuesDoNotMatch) {
+		t.Errorf("expected shown emails not to match, got: %v", errs)
+	}
+}
+
+func TestRunValidators(t *testing.T) {
+	errNotACustomer := errors.New("This is not a customer ID")
+	var ran []string
+	validator := func(name string, err error) frm.Validator {
+		return func(ctx context.Context, field types.FormField, value []string) error {
+			ran = append(ran, name)
+			return err
+		}
+	}
+	validators := map[string]frm.Validator{
+		"first":    validator("first", nil),
+		"second":   validator("second", nil),
+		"customer": validator("customer", errNotACustomer),
+	}
+	ctx := context.Background()
+
+	// validators run in the order in which they're attached to fields, and validators that are not registered are skipped
+	field := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeTextSingle, Validators: []string{"second", "unregistered", "first"}}
+	if err := runValidators(ctx, field, []string{"42"}, validators); err != nil {
+		t.Errorf("expected value to be valid, got: %v", err)
+	}
+	if expected := []string{"second", "first"}; !slices.Equal(ran, expected) {
+		t.Errorf("expected validators %v to run, got: %v", expected, ran)
+	}
+
+	// blank values are not validated
+	ran = nil
+	if err := runValidators(ctx, field, []string{" "}, validators); err != nil || len(ran) > 0 {
+		t.Errorf("expected blank values to skip validation, got: %v and validators %v ran", err, ran)
+	}
+
+	// validators' errors are fields' validation errors, and validation stops at the first error
+	ran = nil
+	field.Validators = []string{"first", "customer", "second"}
+	f := internal.Form{Fields: types.FormFields{field.ID.String(): field}}
+	submission := url.Values{field.ID.String(): {"42"}}
+	errs := validate(ctx, f, submission, validators, f.Fields.EvaluateLogic(submission))
+	if err := errs[field.ID.String()]; !errors.Is(err, errNotACustomer) {
+		t.Errorf("expected validation error %v, got: %v", errNotACustomer, err)
+	}
+	if expected := []string{"first", "customer"}; !slices.Equal(ran, expected) {
+		t.Errorf("expected validators %v to run, got: %v", expected, ran)
+	}
+}
+
+func TestCalculateDivisionByZero(t *testing.T) {
+	price := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeNumber}
+	quantity := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeNumber}
+	unitPrice := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeCalculated, Expression: price.CalculationReference() + " / " + quantity.CalculationReference()}
+	f := internal.Form{Fields: types.FormFields{price.ID.String(): price, quantity.ID.String(): quantity, unitPrice.ID.String(): unitPrice}}
+
+	submission := url.Values{price.ID.String(): {"10"}, quantity.ID.String(): {"0"}, unitPrice.ID.String(): {"5"}}
+	calculations := calculate(f, submission)
+	calculation, ok := calculations[unitPrice.ID.String()]
+	if !ok {
+		t.Fatal("expected calculations that fail to be stored")
+	}
+	if calculation.Value != nil {
+		t.Errorf("expected calculations that divide by zero to be stored without a value, got: %v", calculation.Value)
+	}
+	if submission.Has(unitPrice.ID.String()) {
+		t.Error("expected values submitted for calculated fields to be discarded")
+	}
+
+	submission = url.Values{price.ID.String(): {"10"}, quantity.ID.String(): {"4"}}
+	if value := calculate(f, submission)[unitPrice.ID.String()].Value; value != 2.5 {
+		t.Errorf("expected calculation to be 2.5, got: %v", value)
+	}
+}
@@ -134,4 +134,6 @@ type ShortCode struct {
 	SubjectID   string    `json:"subject_id"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	// data attached to the short code, from which form fields may be prefilled, see types.ShortCodeData
+	Data types.ShortCodeData `json:"data"`
 }
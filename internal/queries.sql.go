@@ -164,7 +164,7 @@ func (q *Queries) GetFormSubmission(ctx context.Context, arg GetFormSubmissionPa
 
 const getShortCode = `-- name: GetShortCode :one
 
-SELECT id, workspace_id, form_id, short_code, subject_id, created_at, updated_at
+SELECT id, workspace_id, form_id, short_code, subject_id, created_at, updated_at, data
 FROM short_codes
 WHERE workspace_id = $1
   AND short_code = $2
@@ -177,7 +177,7 @@ type GetShortCodeParams struct {
 
 // GetShortCode
 //
-//	SELECT id, workspace_id, form_id, short_code, subject_id, created_at, updated_at
+//	SELECT id, workspace_id, form_id, short_code, subject_id, created_at, updated_at, data
 //	FROM short_codes
 //	WHERE workspace_id = $1
 //	  AND short_code = $2
@@ -192,6 +192,7 @@ func (q *Queries) GetShortCode(ctx context.Context, arg GetShortCodeParams) (Sho
 		&i.SubjectID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Data,
 	)
 	return i, err
 }
@@ -433,10 +434,11 @@ func (q *Queries) SaveForm(ctx context.Context, arg SaveFormParams) (Form, error
 
 const saveShortCode = `-- name: SaveShortCode :one
 
-INSERT INTO short_codes (workspace_id, form_id, subject_id, short_code)
-VALUES ($1, $2, $3, $4) ON CONFLICT (subject_id, form_id) DO
+INSERT INTO short_codes (workspace_id, form_id, subject_id, short_code, data)
+VALUES ($1, $2, $3, $4, COALESCE($5::jsonb, '{}'::jsonb)) ON CONFLICT (subject_id, form_id) DO
 UPDATE
-SET updated_at = timezone('utc', now()) RETURNING id, workspace_id, form_id, short_code, subject_id, created_at, updated_at
+SET updated_at = timezone('utc', now()),
+    data = COALESCE($5::jsonb, short_codes.data) RETURNING id, workspace_id, form_id, short_code, subject_id, created_at, updated_at, data
 `
 
 type SaveShortCodeParams struct {
@@ -444,20 +446,23 @@ type SaveShortCodeParams struct {
 	FormID      *int64 `json:"form_id"`
 	SubjectID   string `json:"subject_id"`
 	ShortCode   string `json:"short_code"`
+	Data        []byte `json:"data"`
 }
 
 // SaveShortCode
 //
-//	INSERT INTO short_codes (workspace_id, form_id, subject_id, short_code)
-//	VALUES ($1, $2, $3, $4) ON CONFLICT (subject_id, form_id) DO
+//	INSERT INTO short_codes (workspace_id, form_id, subject_id, short_code, data)
+//	VALUES ($1, $2, $3, $4, COALESCE($5::jsonb, '{}'::jsonb)) ON CONFLICT (subject_id, form_id) DO
 //	UPDATE
-//	SET updated_at = timezone('utc', now()) RETURNING id, workspace_id, form_id, short_code, subject_id, created_at, updated_at
+//	SET updated_at = timezone('utc', now()),
+//	    data = COALESCE($5::jsonb, short_codes.data) RETURNING id, workspace_id, form_id, short_code, subject_id, created_at, updated_at, data
 func (q *Queries) SaveShortCode(ctx context.Context, arg SaveShortCodeParams) (ShortCode, error) {
 	row := q.db.QueryRow(ctx, saveShortCode,
 		arg.WorkspaceID,
 		arg.FormID,
 		arg.SubjectID,
 		arg.ShortCode,
+		arg.Data,
 	)
 	var i ShortCode
 	err := row.Scan(
@@ -468,6 +473,7 @@ func (q *Queries) SaveShortCode(ctx context.Context, arg SaveShortCodeParams) (S
 		&i.SubjectID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Data,
 	)
 	return i, err
 }
@@ -48,6 +48,7 @@ func Mount(router chi.Router, f *frm.Frm) {
 		form.Route(fmt.Sprintf("/fields/{%s}", UrlParamFieldID), func(fields chi.Router) {
 			fields.Delete("/", handlers.DeleteField)
 			fields.Get("/logic/choices", handlers.LogicConfiguratorChoices)
+			fields.Get("/options/parent", handlers.OptionsParentValues)
 		})
 	})
 
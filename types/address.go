@@ -0,0 +1,101 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// The parts of address fields, submitted as "<field id>[<part>]"
+const (
+	AddressPartStreet     = "street"
+	AddressPartCity       = "city"
+	AddressPartRegion     = "region"
+	AddressPartPostalCode = "postal_code"
+	AddressPartCountry    = "country"
+)
+
+var ErrAddressIncomplete = errors.New("This address is incomplete")
+var ErrUnknownAddressPart = errors.New("Please enter only the parts of the address on this form")
+
+// AddressPart describes one part of address fields
+type AddressPart struct {
+	Name         string // the part's name, e.g. [AddressPartStreet]
+	Label        string // the part's label, shown to respondents
+	Autocomplete string // the part's HTML autocomplete token
+}
+
+// AddressParts are the parts of address fields, in the order they appear on forms
+var AddressParts = []AddressPart{
+	{Name: AddressPartStreet, Label: "Street address", Autocomplete: "street-address"},
+	{Name: AddressPartCity, Label: "City", Autocomplete: "address-level2"},
+	{Name: AddressPartRegion, Label: "State / Region", Autocomplete: "address-level1"},
+	{Name: AddressPartPostalCode, Label: "Postal code", Autocomplete: "postal-code"},
+	{Name: AddressPartCountry, Label: "Country", Autocomplete: "country-name"},
+}
+
+// Address is the [FormFieldSubmission.Value] of address fields
+type Address struct {
+	Street     string `json:"street"`
+	City       string `json:"city"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+}
+
+// PartRequired returns whether a part of an address field must be answered
+//
+// When no parts are explicitly required, every part of required address fields is required. Explicitly required parts
+// of optional address fields are required once any part of the address is answered.
+func (f FormField) PartRequired(part string) bool {
+	if len(f.RequiredParts) == 0 {
+		return f.Required
+	}
+	return slices.Contains(f.RequiredParts, part)
+}
+
+// validateAddress validates that only known parts of address fields are answered, and that required parts are answered
+func (f FormField) validateAddress(parts map[string][]string) (err error) {
+	answered := false
+	for part := range parts {
+		if !slices.ContainsFunc(AddressParts, func(p AddressPart) bool { return p.Name == part }) {
+			return ErrUnknownAddressPart
+		}
+		answered = answered || addressPartValue(parts[part]) != ""
+	}
+	if !answered && !f.Required {
+		return nil
+	}
+	for _, part := range AddressParts {
+		if f.PartRequired(part.Name) && addressPartValue(parts[part.Name]) == "" {
+			return fmt.Errorf("%w, please enter the %s", ErrAddressIncomplete, strings.ToLower(part.Label))
+		}
+	}
+	return nil
+}
+
+// addressSubmissionValue converts the parts of address fields to an [Address], or nil when no part is answered
+func (f FormField) addressSubmissionValue(parts map[string][]string) any {
+	address := Address{
+		Street:     addressPartValue(parts[AddressPartStreet]),
+		City:       addressPartValue(parts[AddressPartCity]),
+		Region:     addressPartValue(parts[AddressPartRegion]),
+		PostalCode: addressPartValue(parts[AddressPartPostalCode]),
+		Country:    addressPartValue(parts[AddressPartCountry]),
+	}
+	if address == (Address{}) {
+		return nil
+	}
+	return address
+}
+
+// addressPartValue returns the first non-blank value submitted to a part of an address field
+func addressPartValue(value []string) string {
+	for _, v := range value {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
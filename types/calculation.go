@@ -0,0 +1,273 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidExpression = errors.New("invalid expression")
+var ErrDivisionByZero = errors.New("division by zero")
+var ErrCalculationCycle = errors.New("calculated fields cannot depend on themselves")
+
+// IsNumeric returns whether the field's values are numbers, and may be referenced by calculated fields' expressions
+//
+// Single choice fields whose DataType is numeric or rating are numeric, and their values are the numbers in the labels
+// of their chosen options, e.g. a "1" to "5" scale.
+func (f FormField) IsNumeric() bool {
+	switch f.Type {
+	case FormFieldTypeNumber, FormFieldTypeNPS, FormFieldTypeStarRating, FormFieldTypeCalculated, FormFieldTypeSlider:
+		return true
+	case FormFieldTypeSingleChoice, FormFieldTypeSingleChoiceSpaced:
+		return f.DataType == FormFieldDataTypeNumeric || f.DataType == FormFieldDataTypeRating
+	default:
+		return false
+	}
+}
+
+// NumericValue returns the number submitted to a numeric field
+//
+// ok is false when the field is not numeric, or no valid number was submitted.
+func (f FormField) NumericValue(value []string) (n float64, ok bool) {
+	for _, v := range value {
+		switch {
+		case f.IsRating():
+			rating, err := f.parseRating(v)
+			if err == nil {
+				return float64(rating), true
+			}
+		case f.IsNumeric() && f.HasOptions():
+			if n, ok = f.optionNumber(v); ok {
+				return n, true
+			}
+		case f.IsNumeric():
+			n, err := parseNumber(v)
+			if err == nil {
+				return n, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// CalculationReference returns the token with which calculated fields' expressions reference the field
+func (f FormField) CalculationReference() string {
+	return fmt.Sprintf("{%s}", f.ID)
+}
+
+// References returns the IDs of the fields referenced by a calculated field's expression
+func (f FormField) References() (fieldIDs []string, err error) {
+	e, err := parseExpression(f.Expression)
+	if err != nil {
+		return
+	}
+	return e.references(nil), nil
+}
+
+// ValidateExpression validates that a calculated field's expression is well-formed, and references only numeric fields
+// in fields
+func (f FormField) ValidateExpression(fields FormFields) (err error) {
+	refs, err := f.References()
+	if err != nil {
+		return
+	}
+	for _, ref := range refs {
+		field, ok := fields[ref]
+		if !ok || !field.IsNumeric() {
+			return fmt.Errorf("%w, '%s' does not reference a numeric field", ErrInvalidExpression, ref)
+		}
+	}
+	return
+}
+
+// Calculate computes a calculated field's value from the values submitted to the fields its expression references
+//
+// Referenced fields without a submitted value count as 0. Referenced calculated fields are calculated first.
+func (f FormField) Calculate(fields FormFields, values map[string][]string) (result float64, err error) {
+	return f.calculate(fields, values, map[string]bool{})
+}
+
+func (f FormField) calculate(fields FormFields, values map[string][]string, calculating map[string]bool) (result float64, err error) {
+	if calculating[f.ID.String()] {
+		return 0, ErrCalculationCycle
+	}
+	calculating[f.ID.String()] = true
+	defer delete(calculating, f.ID.String())
+
+	e, err := parseExpression(f.Expression)
+	if err != nil {
+		return
+	}
+	return e.evaluate(func(fieldID string) (n float64, err error) {
+		field, ok := fields[fieldID]
+		if !ok || !field.IsNumeric() {
+			return 0, fmt.Errorf("%w, '%s' does not reference a numeric field", ErrInvalidExpression, fieldID)
+		}
+		if field.Type == FormFieldTypeCalculated {
+			return field.calculate(fields, values, calculating)
+		}
+		n, _ = field.NumericValue(values[fieldID])
+		return n, nil
+	})
+}
+
+// expression is a node of a parsed arithmetic expression
+type expression struct {
+	op          byte // one of '+', '-', '*', '/', 'n' (number), 'r' (field reference), or 'u' (unary minus)
+	number      float64
+	reference   string
+	left, right *expression
+}
+
+func (e *expression) references(refs []string) []string {
+	if e == nil {
+		return refs
+	}
+	if e.op == 'r' {
+		return append(refs, e.reference)
+	}
+	return e.right.references(e.left.references(refs))
+}
+
+func (e *expression) evaluate(resolve func(fieldID string) (float64, error)) (n float64, err error) {
+	switch e.op {
+	case 'n':
+		return e.number, nil
+	case 'r':
+		return resolve(e.reference)
+	case 'u':
+		n, err = e.left.evaluate(resolve)
+		return -n, err
+	}
+	left, err := e.left.evaluate(resolve)
+	if err != nil {
+		return
+	}
+	right, err := e.right.evaluate(resolve)
+	if err != nil {
+		return
+	}
+	switch e.op {
+	case '+':
+		return left + right, nil
+	case '-':
+		return left - right, nil
+	case '*':
+		return left * right, nil
+	default:
+		if right == 0 {
+			return 0, ErrDivisionByZero
+		}
+		return left / right, nil
+	}
+}
+
+// expressionParser is a recursive descent parser for arithmetic expressions of numbers, field references in braces,
+// the operators + - * /, and parentheses
+//
+//	expression = term { ("+" | "-") term }
+//	term       = factor { ("*" | "/") factor }
+//	factor     = number | "{" field id "}" | "(" expression ")" | "-" factor
+type expressionParser struct {
+	input string
+	pos   int
+}
+
+// parseExpression parses arithmetic expressions, e.g. "{price} * {quantity} + 5"
+func parseExpression(input string) (e *expression, err error) {
+	p := &expressionParser{input: input}
+	if strings.TrimSpace(input) == "" {
+		return nil, fmt.Errorf("%w, it is empty", ErrInvalidExpression)
+	}
+	e, err = p.expression()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("%w, unexpected '%c'", ErrInvalidExpression, p.input[p.pos])
+	}
+	return
+}
+
+func (p *expressionParser) skipSpace() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// peek returns the next non-space character, or 0 at the end of input
+func (p *expressionParser) peek() byte {
+	p.skipSpace()
+	if p.pos >= len(p.input) {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *expressionParser) expression() (e *expression, err error) {
+	e, err = p.term()
+	for err == nil && (p.peek() == '+' || p.peek() == '-') {
+		op := p.input[p.pos]
+		p.pos++
+		var right *expression
+		right, err = p.term()
+		e = &expression{op: op, left: e, right: right}
+	}
+	return
+}
+
+func (p *expressionParser) term() (e *expression, err error) {
+	e, err = p.factor()
+	for err == nil && (p.peek() == '*' || p.peek() == '/') {
+		op := p.input[p.pos]
+		p.pos++
+		var right *expression
+		right, err = p.factor()
+		e = &expression{op: op, left: e, right: right}
+	}
+	return
+}
+
+func (p *expressionParser) factor() (e *expression, err error) {
+	switch c := p.peek(); {
+	case c == 0:
+		return nil, fmt.Errorf("%w, it ends unexpectedly", ErrInvalidExpression)
+	case c == '-':
+		p.pos++
+		e, err = p.factor()
+		return &expression{op: 'u', left: e}, err
+	case c == '(':
+		p.pos++
+		e, err = p.expression()
+		if err != nil {
+			return
+		}
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("%w, missing ')'", ErrInvalidExpression)
+		}
+		p.pos++
+		return
+	case c == '{':
+		end := strings.IndexByte(p.input[p.pos:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("%w, missing '}'", ErrInvalidExpression)
+		}
+		ref := strings.TrimSpace(p.input[p.pos+1 : p.pos+end])
+		p.pos += end + 1
+		return &expression{op: 'r', reference: ref}, nil
+	case c == '.' || (c >= '0' && c <= '9'):
+		start := p.pos
+		for p.pos < len(p.input) && (p.input[p.pos] == '.' || (p.input[p.pos] >= '0' && p.input[p.pos] <= '9')) {
+			p.pos++
+		}
+		n, err := parseNumber(p.input[start:p.pos])
+		if err != nil {
+			return nil, fmt.Errorf("%w, '%s' is not a number", ErrInvalidExpression, p.input[start:p.pos])
+		}
+		return &expression{op: 'n', number: n}, nil
+	default:
+		return nil, fmt.Errorf("%w, unexpected '%c'", ErrInvalidExpression, c)
+	}
+}
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// CanCascadeOptions returns whether the options offered by the field may depend on another field's answer, e.g. a
+// region field whose options depend on the chosen country
+//
+// Rankings order every one of their options, so the options they offer may not depend on other answers.
+func (f FormField) CanCascadeOptions() bool {
+	return f.HasOptions() && f.Type != FormFieldTypeRanking
+}
+
+// HasOptionsParent returns whether the options offered by the field depend on the answer to its options parent
+func (f FormField) HasOptionsParent() bool {
+	return f.CanCascadeOptions() && f.OptionsParentID != nil && *f.OptionsParentID != uuid.Nil
+}
+
+// OptionsParent returns the field's options parent from amongst fields
+//
+// ok is false when the options offered by the field do not depend on another field's answer, or its options parent no
+// longer exists.
+func (f FormField) OptionsParent(fields FormFields) (parent FormField, ok bool) {
+	if !f.HasOptionsParent() {
+		return
+	}
+	parent, ok = fields[f.OptionsParentID.String()]
+	return
+}
+
+// OfferedFor returns whether the option is offered when its field's options parent is answered with 'parent'
+//
+// Options without parent values are always offered. Other options are offered when any of the parent's answers is one
+// of their parent values.
+func (o Option) OfferedFor(parent []string) bool {
+	if len(o.ParentValues) == 0 {
+		return true
+	}
+	for _, answer := range parent {
+		answer = strings.TrimSpace(answer)
+		if answer == "" {
+			continue
+		}
+		if slices.ContainsFunc(o.ParentValues, func(v string) bool { return strings.EqualFold(v, answer) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// OfferedOptions returns a copy of the field's options in which the options that are not offered when its options
+// parent is answered with 'parent' are disabled
+func (f FormField) OfferedOptions(parent []string) FieldOptions {
+	options := slices.Clone(f.Options)
+	if !f.HasOptionsParent() {
+		return options
+	}
+	for i, option := range options {
+		options[i].Disabled = !option.OfferedFor(parent)
+	}
+	return options
+}
+
+// allOffered checks that none of the field submission values are options that are not offered to respondents when the
+// field's options parent is answered with 'parent'
+func allOffered(field FormField, subset, parent []string) bool {
+	if !field.HasOptionsParent() {
+		return true
+	}
+	for _, option := range field.Options {
+		if !option.OfferedFor(parent) && slices.Contains(subset, option.Value) {
+			return false
+		}
+	}
+	return true
+}
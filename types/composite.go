@@ -0,0 +1,43 @@
+package types
+
+// IsComposite returns whether the field is made up of parts that are submitted separately, e.g. the rows of matrix
+// fields
+//
+// Parts are submitted as "<field id>[<part>]", and are validated with [FormField.ValidateParts].
+func (f FormField) IsComposite() bool {
+	switch f.Type {
+	case FormFieldTypeMatrix, FormFieldTypeAddress:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidateParts validates values submitted to the parts of composite fields, keyed by part
+//
+// Matrix fields' parts are their rows, and address fields' parts are the components of the address, e.g. the city.
+func (f FormField) ValidateParts(parts map[string][]string) (err error) {
+	switch f.Type {
+	case FormFieldTypeMatrix:
+		return f.validateMatrix(parts)
+	case FormFieldTypeAddress:
+		return f.validateAddress(parts)
+	default:
+		return nil
+	}
+}
+
+// PartsSubmissionValue converts values submitted to the parts of composite fields to the value stored in
+// [FormFieldSubmission.Value]
+//
+// Matrix fields are stored as a mapping of row values to the chosen option values, and address fields as an [Address].
+func (f FormField) PartsSubmissionValue(parts map[string][]string) any {
+	switch f.Type {
+	case FormFieldTypeMatrix:
+		return f.matrixSubmissionValue(parts)
+	case FormFieldTypeAddress:
+		return f.addressSubmissionValue(parts)
+	default:
+		return nil
+	}
+}
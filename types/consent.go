@@ -0,0 +1,48 @@
+package types
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+)
+
+// ConsentValue is the value submitted by consent fields when they are ticked
+//
+// Consent fields are always accompanied by a hidden input with the value "false", so that unticked consent fields are
+// submitted, and stored, as false rather than being omitted from submissions.
+const ConsentValue = "true"
+
+var ErrConsentRequired = errors.New("Please tick this box to continue")
+
+// consentLabelLink matches markdown-style links in consent field labels, e.g. "I accept the [terms](https://...)"
+var consentLabelLink = regexp.MustCompile(`\[([^\]]+)\]\((https?://[^)\s]+)\)`)
+
+// LabelSegment is a segment of a field's label, which links to URL when URL is non-empty
+type LabelSegment struct {
+	Text string
+	URL  string
+}
+
+// LabelSegments splits the field's label into plain text and link segments
+//
+// Links are written in markdown style, e.g. "I accept the [terms of service](https://example.com/terms)". Only http
+// and https links are recognized.
+func (f FormField) LabelSegments() (segments []LabelSegment) {
+	last := 0
+	for _, match := range consentLabelLink.FindAllStringSubmatchIndex(f.Label, -1) {
+		if match[0] > last {
+			segments = append(segments, LabelSegment{Text: f.Label[last:match[0]]})
+		}
+		segments = append(segments, LabelSegment{Text: f.Label[match[2]:match[3]], URL: f.Label[match[4]:match[5]]})
+		last = match[1]
+	}
+	if last < len(f.Label) {
+		segments = append(segments, LabelSegment{Text: f.Label[last:]})
+	}
+	return
+}
+
+// consented returns whether the values submitted to a consent field include consent
+func consented(value []string) bool {
+	return slices.Contains(value, ConsentValue)
+}
@@ -0,0 +1,14 @@
+package types
+
+// IsContent returns whether the field is a display-only content block, e.g. a heading or divider
+//
+// Content blocks are ordered alongside inputs, but accept no values. They are not validated and never appear in
+// [FormFieldValues].
+func (f FormField) IsContent() bool {
+	switch f.Type {
+	case FormFieldTypeHeading, FormFieldTypeParagraph, FormFieldTypeDivider, FormFieldTypeImage:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,151 @@
+package types
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var ErrValuesDoNotMatch = errors.New("This answer does not match")
+var ErrValuesOutOfOrder = errors.New("This answer is out of order")
+var ErrAtLeastOneRequired = errors.New("Please answer at least one of these questions")
+
+// CrossFieldRule relates a field's value to the values of other fields, e.g. "confirm email equals email"
+//
+// Rules are evaluated by [FormField.ValidateCrossField], and violations are reported on the field that the rule belongs
+// to.
+type CrossFieldRule struct {
+	Kind     CrossFieldRuleKind `json:"kind"`              // the kind of rule
+	FieldIDs []uuid.UUID        `json:"field_ids"`         // IDs of the other fields that the rule relates the field to
+	Message  string             `json:"message,omitempty"` // message shown to respondents when the rule is violated
+}
+
+// CanCompareAcrossFields returns whether the field's values may be related to other fields' values by cross-field rules
+//
+// Calculated fields are computed after submissions are validated, and signatures are images, so neither may be compared.
+func (f FormField) CanCompareAcrossFields() bool {
+	switch f.Type {
+	case FormFieldTypeCalculated, FormFieldTypeSignature:
+		return false
+	default:
+		return !f.IsContent() && !f.IsComposite()
+	}
+}
+
+// ValidateCrossField validates the rules relating the field's values to other fields' values, keyed by field ID
+//
+// Rules referring to fields that are not on the form are ignored, as are ordering rules for values that cannot be
+// ordered, e.g. invalid dates, which are reported by [FormField.Validate].
+func (f FormField) ValidateCrossField(fields FormFields, values map[string][]string) (err error) {
+	for _, rule := range f.CrossFieldRules {
+		if err = f.validateCrossFieldRule(rule, fields, values); err == nil {
+			continue
+		}
+		if message := strings.TrimSpace(rule.Message); message != "" {
+			return ruleError{err: err, message: message}
+		}
+		return err
+	}
+	return nil
+}
+
+// validateCrossFieldRule validates a single cross-field rule
+func (f FormField) validateCrossFieldRule(rule CrossFieldRule, fields FormFields, values map[string][]string) (err error) {
+	value := answer(values[f.ID.String()])
+	others := []FormField{}
+	for _, id := range rule.FieldIDs {
+		if other, ok := fields[id.String()]; ok && other.ID != f.ID {
+			others = append(others, other)
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+
+	switch rule.Kind {
+	case CrossFieldRuleKindEqual:
+		for _, other := range others {
+			otherValue := answer(values[other.ID.String()])
+			// email addresses' domains are case-insensitive, but their local parts may not be
+			if f.Type == FormFieldTypeEmail || other.Type == FormFieldTypeEmail {
+				value, _ = normalizeEmail(value)
+				otherValue, _ = normalizeEmail(otherValue)
+			}
+			if value != otherValue {
+				return fmt.Errorf("%w '%s'", ErrValuesDoNotMatch, other.Label)
+			}
+		}
+	case CrossFieldRuleKindAfter, CrossFieldRuleKindBefore:
+		for _, other := range others {
+			order, ok := f.compareAnswers(value, other, answer(values[other.ID.String()]))
+			if !ok {
+				continue
+			}
+			if rule.Kind == CrossFieldRuleKindAfter && order <= 0 {
+				return fmt.Errorf("%w, it must be after '%s'", ErrValuesOutOfOrder, other.Label)
+			}
+			if rule.Kind == CrossFieldRuleKindBefore && order >= 0 {
+				return fmt.Errorf("%w, it must be before '%s'", ErrValuesOutOfOrder, other.Label)
+			}
+		}
+	case CrossFieldRuleKindAtLeastOne:
+		if value != "" || slices.ContainsFunc(others, func(other FormField) bool { return answer(values[other.ID.String()]) != "" }) {
+			return nil
+		}
+		labels := []string{f.Label}
+		for _, other := range others {
+			labels = append(labels, other.Label)
+		}
+		return fmt.Errorf("%w: %s", ErrAtLeastOneRequired, strings.Join(labels, ", "))
+	}
+	return nil
+}
+
+// compareAnswers compares the field's answer with another field's answer, returning -1, 0 or 1 when the answer is
+// before, the same as, or after the other answer
+//
+// ok is false when either answer is missing, or the answers cannot be ordered, e.g. comparing dates with numbers.
+func (f FormField) compareAnswers(value string, other FormField, otherValue string) (order int, ok bool) {
+	if value == "" || otherValue == "" {
+		return 0, false
+	}
+	switch {
+	case f.IsTemporal() && other.IsTemporal():
+		t, err := f.parseTemporal(value)
+		if err != nil {
+			return 0, false
+		}
+		otherT, err := other.parseTemporal(otherValue)
+		if err != nil {
+			return 0, false
+		}
+		return t.Compare(otherT), true
+	case f.IsNumeric() && other.IsNumeric():
+		n, ok := f.NumericValue([]string{value})
+		if !ok {
+			return 0, false
+		}
+		otherN, ok := other.NumericValue([]string{otherValue})
+		if !ok {
+			return 0, false
+		}
+		return cmp.Compare(n, otherN), true
+	default:
+		return 0, false
+	}
+}
+
+// answer joins the non-blank values submitted to a field, so that multi-valued answers may be compared
+func answer(value []string) string {
+	answered := []string{}
+	for _, v := range value {
+		if v = strings.TrimSpace(v); v != "" {
+			answered = append(answered, v)
+		}
+	}
+	return strings.Join(answered, ",")
+}
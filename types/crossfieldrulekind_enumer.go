@@ -0,0 +1,116 @@
+// Code generated by "enumer -type CrossFieldRuleKind -trimprefix CrossFieldRuleKind -transform=snake -json -text"; DO NOT EDIT.
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _CrossFieldRuleKindName = "equalafterbeforeat_least_one"
+
+var _CrossFieldRuleKindIndex = [...]uint8{0, 5, 10, 16, 28}
+
+const _CrossFieldRuleKindLowerName = "equalafterbeforeat_least_one"
+
+func (i CrossFieldRuleKind) String() string {
+	if i < 0 || i >= CrossFieldRuleKind(len(_CrossFieldRuleKindIndex)-1) {
+		return fmt.Sprintf("CrossFieldRuleKind(%d)", i)
+	}
+	return _CrossFieldRuleKindName[_CrossFieldRuleKindIndex[i]:_CrossFieldRuleKindIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _CrossFieldRuleKindNoOp() {
+	var x [1]struct{}
+	_ = x[CrossFieldRuleKindEqual-(0)]
+	_ = x[CrossFieldRuleKindAfter-(1)]
+	_ = x[CrossFieldRuleKindBefore-(2)]
+	_ = x[CrossFieldRuleKindAtLeastOne-(3)]
+}
+
+var _CrossFieldRuleKindValues = []CrossFieldRuleKind{CrossFieldRuleKindEqual, CrossFieldRuleKindAfter, CrossFieldRuleKindBefore, CrossFieldRuleKindAtLeastOne}
+
+var _CrossFieldRuleKindNameToValueMap = map[string]CrossFieldRuleKind{
+	_CrossFieldRuleKindName[0:5]:        CrossFieldRuleKindEqual,
+	_CrossFieldRuleKindLowerName[0:5]:   CrossFieldRuleKindEqual,
+	_CrossFieldRuleKindName[5:10]:       CrossFieldRuleKindAfter,
+	_CrossFieldRuleKindLowerName[5:10]:  CrossFieldRuleKindAfter,
+	_CrossFieldRuleKindName[10:16]:      CrossFieldRuleKindBefore,
+	_CrossFieldRuleKindLowerName[10:16]: CrossFieldRuleKindBefore,
+	_CrossFieldRuleKindName[16:28]:      CrossFieldRuleKindAtLeastOne,
+	_CrossFieldRuleKindLowerName[16:28]: CrossFieldRuleKindAtLeastOne,
+}
+
+var _CrossFieldRuleKindNames = []string{
+	_CrossFieldRuleKindName[0:5],
+	_CrossFieldRuleKindName[5:10],
+	_CrossFieldRuleKindName[10:16],
+	_CrossFieldRuleKindName[16:28],
+}
+
+// CrossFieldRuleKindString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func CrossFieldRuleKindString(s string) (CrossFieldRuleKind, error) {
+	if val, ok := _CrossFieldRuleKindNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _CrossFieldRuleKindNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to CrossFieldRuleKind values", s)
+}
+
+// CrossFieldRuleKindValues returns all values of the enum
+func CrossFieldRuleKindValues() []CrossFieldRuleKind {
+	return _CrossFieldRuleKindValues
+}
+
+// CrossFieldRuleKindStrings returns a slice of all String values of the enum
+func CrossFieldRuleKindStrings() []string {
+	strs := make([]string, len(_CrossFieldRuleKindNames))
+	copy(strs, _CrossFieldRuleKindNames)
+	return strs
+}
+
+// IsACrossFieldRuleKind returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i CrossFieldRuleKind) IsACrossFieldRuleKind() bool {
+	for _, v := range _CrossFieldRuleKindValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for CrossFieldRuleKind
+func (i CrossFieldRuleKind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for CrossFieldRuleKind
+func (i *CrossFieldRuleKind) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("CrossFieldRuleKind should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = CrossFieldRuleKindString(s)
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for CrossFieldRuleKind
+func (i CrossFieldRuleKind) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for CrossFieldRuleKind
+func (i *CrossFieldRuleKind) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = CrossFieldRuleKindString(string(text))
+	return err
+}
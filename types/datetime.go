@@ -0,0 +1,151 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateBoundNow is a value for [FormField.Earliest] and [FormField.Latest] that is relative to the time of submission,
+// e.g. setting Earliest to DateBoundNow disallows dates in the past
+//
+// "now" is the current date and time in UTC, regardless of the server's and respondents' time zones, so respondents
+// far from UTC may find that "today" is already tomorrow or still yesterday.
+const DateBoundNow = "now"
+
+// ISO-8601 layouts in which date, time and datetime field values are stored
+const (
+	DateLayout     = "2006-01-02"
+	TimeLayout     = "15:04:05"
+	DateTimeLayout = "2006-01-02T15:04:05"
+)
+
+var ErrInvalidDate = errors.New("Please enter a valid date")
+var ErrInvalidTime = errors.New("Please enter a valid time")
+var ErrInvalidDateTime = errors.New("Please enter a valid date and time")
+var ErrDateOutOfRange = errors.New("This date is out of range")
+var ErrTimeOutOfRange = errors.New("This time is out of range")
+
+// IsTemporal returns whether the field is a date, time or datetime field
+func (f FormField) IsTemporal() bool {
+	switch f.Type {
+	case FormFieldTypeDate, FormFieldTypeTime, FormFieldTypeDateTime:
+		return true
+	default:
+		return false
+	}
+}
+
+// TemporalInputLayout is the layout of values for the field's HTML input, i.e. <input type="date|time|datetime-local">
+func (f FormField) TemporalInputLayout() string {
+	switch f.Type {
+	case FormFieldTypeTime:
+		return "15:04"
+	case FormFieldTypeDateTime:
+		return "2006-01-02T15:04"
+	default:
+		return DateLayout
+	}
+}
+
+// TemporalBound returns the field's earliest or latest bound formatted for the field's HTML input. Relative bounds are
+// resolved relative to now, in UTC.
+//
+// ok is false when the bound is not set or is not valid for the field's type
+func (f FormField) TemporalBound(bound string, now time.Time) (value string, ok bool) {
+	t, ok := f.resolveTemporalBound(bound, now)
+	if !ok {
+		return "", false
+	}
+	return t.Format(f.TemporalInputLayout()), true
+}
+
+// storedTemporalLayout is the ISO-8601 layout in which the field's values are stored
+func (f FormField) storedTemporalLayout() string {
+	switch f.Type {
+	case FormFieldTypeTime:
+		return TimeLayout
+	case FormFieldTypeDateTime:
+		return DateTimeLayout
+	default:
+		return DateLayout
+	}
+}
+
+// parseTemporal parses date, time and datetime values as they are submitted by HTML inputs, or as they are stored
+func (f FormField) parseTemporal(value string) (t time.Time, err error) {
+	value = strings.TrimSpace(value)
+	layouts := []string{f.storedTemporalLayout(), f.TemporalInputLayout()}
+	for _, layout := range layouts {
+		t, err = time.Parse(layout, value)
+		if err == nil {
+			return
+		}
+	}
+	switch f.Type {
+	case FormFieldTypeTime:
+		err = ErrInvalidTime
+	case FormFieldTypeDateTime:
+		err = ErrInvalidDateTime
+	default:
+		err = ErrInvalidDate
+	}
+	return
+}
+
+// resolveTemporalBound resolves the earliest/latest bound for the field, truncating relative bounds to the precision
+// of the field's type, i.e. "now" for date fields is the current date in UTC
+func (f FormField) resolveTemporalBound(bound string, now time.Time) (t time.Time, ok bool) {
+	bound = strings.TrimSpace(bound)
+	if bound == "" {
+		return
+	}
+	if strings.EqualFold(bound, DateBoundNow) {
+		bound = now.UTC().Format(f.TemporalInputLayout())
+	}
+	t, err := f.parseTemporal(bound)
+	return t, err == nil
+}
+
+// validateTemporal validates that a value is a date/time that falls within the field's earliest and latest bounds
+func (f FormField) validateTemporal(value string, now time.Time) (err error) {
+	t, err := f.parseTemporal(value)
+	if err != nil {
+		return err
+	}
+	errOutOfRange := ErrDateOutOfRange
+	if f.Type == FormFieldTypeTime {
+		errOutOfRange = ErrTimeOutOfRange
+	}
+	if earliest, ok := f.resolveTemporalBound(f.Earliest, now); ok && t.Before(earliest) {
+		return fmt.Errorf("%w, it must not be before %s", errOutOfRange, f.describeTemporalBound(f.Earliest, earliest))
+	}
+	if latest, ok := f.resolveTemporalBound(f.Latest, now); ok && t.After(latest) {
+		return fmt.Errorf("%w, it must not be after %s", errOutOfRange, f.describeTemporalBound(f.Latest, latest))
+	}
+	return nil
+}
+
+// describeTemporalBound describes earliest/latest bounds to respondents
+func (f FormField) describeTemporalBound(bound string, resolved time.Time) string {
+	if strings.EqualFold(strings.TrimSpace(bound), DateBoundNow) {
+		if f.Type == FormFieldTypeDate {
+			return "today"
+		}
+		return "now"
+	}
+	return resolved.Format(f.TemporalInputLayout())
+}
+
+// temporalSubmissionValue converts a submitted date, time or datetime to its ISO-8601 representation
+func (f FormField) temporalSubmissionValue(value []string) any {
+	for _, v := range value {
+		t, err := f.parseTemporal(v)
+		if err != nil {
+			continue
+		}
+		return t.Format(f.storedTemporalLayout())
+	}
+	return nil
+}
@@ -8,11 +8,11 @@ import (
 	"strings"
 )
 
-const _FieldLogicComparatorName = "equalcontainsnot"
+const _FieldLogicComparatorName = "equalcontainsnotgreater_thanless_thanbetweenis_emptyis_not_empty"
 
-var _FieldLogicComparatorIndex = [...]uint8{0, 5, 13, 16}
+var _FieldLogicComparatorIndex = [...]uint8{0, 5, 13, 16, 28, 37, 44, 52, 64}
 
-const _FieldLogicComparatorLowerName = "equalcontainsnot"
+const _FieldLogicComparatorLowerName = "equalcontainsnotgreater_thanless_thanbetweenis_emptyis_not_empty"
 
 func (i FieldLogicComparator) String() string {
 	if i < 0 || i >= FieldLogicComparator(len(_FieldLogicComparatorIndex)-1) {
@@ -28,9 +28,14 @@ func _FieldLogicComparatorNoOp() {
 	_ = x[FieldLogicComparatorEqual-(0)]
 	_ = x[FieldLogicComparatorContains-(1)]
 	_ = x[FieldLogicComparatorNot-(2)]
+	_ = x[FieldLogicComparatorGreaterThan-(3)]
+	_ = x[FieldLogicComparatorLessThan-(4)]
+	_ = x[FieldLogicComparatorBetween-(5)]
+	_ = x[FieldLogicComparatorIsEmpty-(6)]
+	_ = x[FieldLogicComparatorIsNotEmpty-(7)]
 }
 
-var _FieldLogicComparatorValues = []FieldLogicComparator{FieldLogicComparatorEqual, FieldLogicComparatorContains, FieldLogicComparatorNot}
+var _FieldLogicComparatorValues = []FieldLogicComparator{FieldLogicComparatorEqual, FieldLogicComparatorContains, FieldLogicComparatorNot, FieldLogicComparatorGreaterThan, FieldLogicComparatorLessThan, FieldLogicComparatorBetween, FieldLogicComparatorIsEmpty, FieldLogicComparatorIsNotEmpty}
 
 var _FieldLogicComparatorNameToValueMap = map[string]FieldLogicComparator{
 	_FieldLogicComparatorName[0:5]:        FieldLogicComparatorEqual,
@@ -39,12 +44,27 @@ var _FieldLogicComparatorNameToValueMap = map[string]FieldLogicComparator{
 	_FieldLogicComparatorLowerName[5:13]:  FieldLogicComparatorContains,
 	_FieldLogicComparatorName[13:16]:      FieldLogicComparatorNot,
 	_FieldLogicComparatorLowerName[13:16]: FieldLogicComparatorNot,
+	_FieldLogicComparatorName[16:28]:      FieldLogicComparatorGreaterThan,
+	_FieldLogicComparatorLowerName[16:28]: FieldLogicComparatorGreaterThan,
+	_FieldLogicComparatorName[28:37]:      FieldLogicComparatorLessThan,
+	_FieldLogicComparatorLowerName[28:37]: FieldLogicComparatorLessThan,
+	_FieldLogicComparatorName[37:44]:      FieldLogicComparatorBetween,
+	_FieldLogicComparatorLowerName[37:44]: FieldLogicComparatorBetween,
+	_FieldLogicComparatorName[44:52]:      FieldLogicComparatorIsEmpty,
+	_FieldLogicComparatorLowerName[44:52]: FieldLogicComparatorIsEmpty,
+	_FieldLogicComparatorName[52:64]:      FieldLogicComparatorIsNotEmpty,
+	_FieldLogicComparatorLowerName[52:64]: FieldLogicComparatorIsNotEmpty,
 }
 
 var _FieldLogicComparatorNames = []string{
 	_FieldLogicComparatorName[0:5],
 	_FieldLogicComparatorName[5:13],
 	_FieldLogicComparatorName[13:16],
+	_FieldLogicComparatorName[16:28],
+	_FieldLogicComparatorName[28:37],
+	_FieldLogicComparatorName[37:44],
+	_FieldLogicComparatorName[44:52],
+	_FieldLogicComparatorName[52:64],
 }
 
 // FieldLogicComparatorString retrieves an enum value from the enum constants string name.
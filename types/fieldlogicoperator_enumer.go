@@ -0,0 +1,108 @@
+// Code generated by "enumer -type FieldLogicOperator -trimprefix FieldLogicOperator -transform=snake -json -text"; DO NOT EDIT.
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _FieldLogicOperatorName = "andor"
+
+var _FieldLogicOperatorIndex = [...]uint8{0, 3, 5}
+
+const _FieldLogicOperatorLowerName = "andor"
+
+func (i FieldLogicOperator) String() string {
+	if i < 0 || i >= FieldLogicOperator(len(_FieldLogicOperatorIndex)-1) {
+		return fmt.Sprintf("FieldLogicOperator(%d)", i)
+	}
+	return _FieldLogicOperatorName[_FieldLogicOperatorIndex[i]:_FieldLogicOperatorIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _FieldLogicOperatorNoOp() {
+	var x [1]struct{}
+	_ = x[FieldLogicOperatorAnd-(0)]
+	_ = x[FieldLogicOperatorOr-(1)]
+}
+
+var _FieldLogicOperatorValues = []FieldLogicOperator{FieldLogicOperatorAnd, FieldLogicOperatorOr}
+
+var _FieldLogicOperatorNameToValueMap = map[string]FieldLogicOperator{
+	_FieldLogicOperatorName[0:3]:      FieldLogicOperatorAnd,
+	_FieldLogicOperatorLowerName[0:3]: FieldLogicOperatorAnd,
+	_FieldLogicOperatorName[3:5]:      FieldLogicOperatorOr,
+	_FieldLogicOperatorLowerName[3:5]: FieldLogicOperatorOr,
+}
+
+var _FieldLogicOperatorNames = []string{
+	_FieldLogicOperatorName[0:3],
+	_FieldLogicOperatorName[3:5],
+}
+
+// FieldLogicOperatorString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func FieldLogicOperatorString(s string) (FieldLogicOperator, error) {
+	if val, ok := _FieldLogicOperatorNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _FieldLogicOperatorNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to FieldLogicOperator values", s)
+}
+
+// FieldLogicOperatorValues returns all values of the enum
+func FieldLogicOperatorValues() []FieldLogicOperator {
+	return _FieldLogicOperatorValues
+}
+
+// FieldLogicOperatorStrings returns a slice of all String values of the enum
+func FieldLogicOperatorStrings() []string {
+	strs := make([]string, len(_FieldLogicOperatorNames))
+	copy(strs, _FieldLogicOperatorNames)
+	return strs
+}
+
+// IsAFieldLogicOperator returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i FieldLogicOperator) IsAFieldLogicOperator() bool {
+	for _, v := range _FieldLogicOperatorValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for FieldLogicOperator
+func (i FieldLogicOperator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for FieldLogicOperator
+func (i *FieldLogicOperator) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("FieldLogicOperator should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = FieldLogicOperatorString(s)
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for FieldLogicOperator
+func (i FieldLogicOperator) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for FieldLogicOperator
+func (i *FieldLogicOperator) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = FieldLogicOperatorString(string(text))
+	return err
+}
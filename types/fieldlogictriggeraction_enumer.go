@@ -8,11 +8,11 @@ import (
 	"strings"
 )
 
-const _FieldLogicTriggerActionName = "field_logic_trigger_showfield_logic_trigger_require"
+const _FieldLogicTriggerActionName = "field_logic_trigger_showfield_logic_trigger_requirefield_logic_trigger_hidefield_logic_trigger_disablefield_logic_trigger_set_valuefield_logic_trigger_end_form"
 
-var _FieldLogicTriggerActionIndex = [...]uint8{0, 24, 51}
+var _FieldLogicTriggerActionIndex = [...]uint8{0, 24, 51, 75, 102, 131, 159}
 
-const _FieldLogicTriggerActionLowerName = "field_logic_trigger_showfield_logic_trigger_require"
+const _FieldLogicTriggerActionLowerName = "field_logic_trigger_showfield_logic_trigger_requirefield_logic_trigger_hidefield_logic_trigger_disablefield_logic_trigger_set_valuefield_logic_trigger_end_form"
 
 func (i FieldLogicTriggerAction) String() string {
 	if i < 0 || i >= FieldLogicTriggerAction(len(_FieldLogicTriggerActionIndex)-1) {
@@ -27,20 +27,36 @@ func _FieldLogicTriggerActionNoOp() {
 	var x [1]struct{}
 	_ = x[FieldLogicTriggerShow-(0)]
 	_ = x[FieldLogicTriggerRequire-(1)]
+	_ = x[FieldLogicTriggerHide-(2)]
+	_ = x[FieldLogicTriggerDisable-(3)]
+	_ = x[FieldLogicTriggerSetValue-(4)]
+	_ = x[FieldLogicTriggerEndForm-(5)]
 }
 
-var _FieldLogicTriggerActionValues = []FieldLogicTriggerAction{FieldLogicTriggerShow, FieldLogicTriggerRequire}
+var _FieldLogicTriggerActionValues = []FieldLogicTriggerAction{FieldLogicTriggerShow, FieldLogicTriggerRequire, FieldLogicTriggerHide, FieldLogicTriggerDisable, FieldLogicTriggerSetValue, FieldLogicTriggerEndForm}
 
 var _FieldLogicTriggerActionNameToValueMap = map[string]FieldLogicTriggerAction{
-	_FieldLogicTriggerActionName[0:24]:       FieldLogicTriggerShow,
-	_FieldLogicTriggerActionLowerName[0:24]:  FieldLogicTriggerShow,
-	_FieldLogicTriggerActionName[24:51]:      FieldLogicTriggerRequire,
-	_FieldLogicTriggerActionLowerName[24:51]: FieldLogicTriggerRequire,
+	_FieldLogicTriggerActionName[0:24]:         FieldLogicTriggerShow,
+	_FieldLogicTriggerActionLowerName[0:24]:    FieldLogicTriggerShow,
+	_FieldLogicTriggerActionName[24:51]:        FieldLogicTriggerRequire,
+	_FieldLogicTriggerActionLowerName[24:51]:   FieldLogicTriggerRequire,
+	_FieldLogicTriggerActionName[51:75]:        FieldLogicTriggerHide,
+	_FieldLogicTriggerActionLowerName[51:75]:   FieldLogicTriggerHide,
+	_FieldLogicTriggerActionName[75:102]:       FieldLogicTriggerDisable,
+	_FieldLogicTriggerActionLowerName[75:102]:  FieldLogicTriggerDisable,
+	_FieldLogicTriggerActionName[102:131]:      FieldLogicTriggerSetValue,
+	_FieldLogicTriggerActionLowerName[102:131]: FieldLogicTriggerSetValue,
+	_FieldLogicTriggerActionName[131:159]:      FieldLogicTriggerEndForm,
+	_FieldLogicTriggerActionLowerName[131:159]: FieldLogicTriggerEndForm,
 }
 
 var _FieldLogicTriggerActionNames = []string{
 	_FieldLogicTriggerActionName[0:24],
 	_FieldLogicTriggerActionName[24:51],
+	_FieldLogicTriggerActionName[51:75],
+	_FieldLogicTriggerActionName[75:102],
+	_FieldLogicTriggerActionName[102:131],
+	_FieldLogicTriggerActionName[131:159],
 }
 
 // FieldLogicTriggerActionString retrieves an enum value from the enum constants string name.
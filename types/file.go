@@ -0,0 +1,72 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+)
+
+var ErrFileTooLarge = errors.New("This file is too large")
+var ErrFileTypeNotAllowed = errors.New("This type of file is not allowed")
+var ErrTooManyFiles = errors.New("Please upload a single file")
+
+// FileReference references a file uploaded to a form field, and is the [FormFieldSubmission.Value] of file fields
+//
+// The file's contents are resolved by passing Key to the [frm.FileStore] that stored it.
+type FileReference struct {
+	Key         string `json:"key"`          // the key under which the file is stored
+	Name        string `json:"name"`         // the file's name on the respondent's device
+	ContentType string `json:"content_type"` // the file's MIME type
+	Size        int64  `json:"size"`         // the file's size in bytes
+}
+
+// ValidateFile validates the content type and size of files uploaded to file fields
+func (f FormField) ValidateFile(contentType string, size int64) (err error) {
+	if f.MaxFileSize > 0 && size > f.MaxFileSize {
+		return fmt.Errorf("%w, the maximum size is %s", ErrFileTooLarge, FormatFileSize(f.MaxFileSize))
+	}
+	if !f.AcceptsContentType(contentType) {
+		return ErrFileTypeNotAllowed
+	}
+	return nil
+}
+
+// AcceptsContentType returns whether files of the content type may be uploaded to the field, i.e. the field accepts
+// any type of file, or the content type is one of its MimeTypes
+func (f FormField) AcceptsContentType(contentType string) bool {
+	if len(f.MimeTypes) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range f.MimeTypes {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == mediaType {
+			return true
+		}
+		// wildcard subtypes, e.g. image/*
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatFileSize formats file sizes in bytes as human-readable sizes, e.g. 1048576 -> "1 MB"
+func FormatFileSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	n := float64(size)
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		n /= unit
+		if n < unit || suffix == "GB" {
+			return fmt.Sprintf("%s %s", strings.TrimSuffix(fmt.Sprintf("%.1f", n), ".0"), suffix)
+		}
+	}
+	return fmt.Sprintf("%d B", size)
+}
@@ -8,11 +8,11 @@ import (
 	"strings"
 )
 
-const _FormFieldTypeName = "text_singletext_multiplesingle_selectmulti_selectsingle_choicesingle_choice_spaced"
+const _FormFieldTypeName = "text_singletext_multiplesingle_selectmulti_selectsingle_choicesingle_choice_spacedemailnumberdatetimedate_timefilenpsstar_ratingcheckbox_groupconsentmatrixheadingparagraphdividerimagerankingphonesignaturecalculatedaddressslider"
 
-var _FormFieldTypeIndex = [...]uint8{0, 11, 24, 37, 49, 62, 82}
+var _FormFieldTypeIndex = [...]uint8{0, 11, 24, 37, 49, 62, 82, 87, 93, 97, 101, 110, 114, 117, 128, 142, 149, 155, 162, 171, 178, 183, 190, 195, 204, 214, 221, 227}
 
-const _FormFieldTypeLowerName = "text_singletext_multiplesingle_selectmulti_selectsingle_choicesingle_choice_spaced"
+const _FormFieldTypeLowerName = "text_singletext_multiplesingle_selectmulti_selectsingle_choicesingle_choice_spacedemailnumberdatetimedate_timefilenpsstar_ratingcheckbox_groupconsentmatrixheadingparagraphdividerimagerankingphonesignaturecalculatedaddressslider"
 
 func (i FormFieldType) String() string {
 	if i < 0 || i >= FormFieldType(len(_FormFieldTypeIndex)-1) {
@@ -31,23 +31,86 @@ func _FormFieldTypeNoOp() {
 	_ = x[FormFieldTypeMultiSelect-(3)]
 	_ = x[FormFieldTypeSingleChoice-(4)]
 	_ = x[FormFieldTypeSingleChoiceSpaced-(5)]
+	_ = x[FormFieldTypeEmail-(6)]
+	_ = x[FormFieldTypeNumber-(7)]
+	_ = x[FormFieldTypeDate-(8)]
+	_ = x[FormFieldTypeTime-(9)]
+	_ = x[FormFieldTypeDateTime-(10)]
+	_ = x[FormFieldTypeFile-(11)]
+	_ = x[FormFieldTypeNPS-(12)]
+	_ = x[FormFieldTypeStarRating-(13)]
+	_ = x[FormFieldTypeCheckboxGroup-(14)]
+	_ = x[FormFieldTypeConsent-(15)]
+	_ = x[FormFieldTypeMatrix-(16)]
+	_ = x[FormFieldTypeHeading-(17)]
+	_ = x[FormFieldTypeParagraph-(18)]
+	_ = x[FormFieldTypeDivider-(19)]
+	_ = x[FormFieldTypeImage-(20)]
+	_ = x[FormFieldTypeRanking-(21)]
+	_ = x[FormFieldTypePhone-(22)]
+	_ = x[FormFieldTypeSignature-(23)]
+	_ = x[FormFieldTypeCalculated-(24)]
+	_ = x[FormFieldTypeAddress-(25)]
+	_ = x[FormFieldTypeSlider-(26)]
 }
 
-var _FormFieldTypeValues = []FormFieldType{FormFieldTypeTextSingle, FormFieldTypeTextMultiple, FormFieldTypeSingleSelect, FormFieldTypeMultiSelect, FormFieldTypeSingleChoice, FormFieldTypeSingleChoiceSpaced}
+var _FormFieldTypeValues = []FormFieldType{FormFieldTypeTextSingle, FormFieldTypeTextMultiple, FormFieldTypeSingleSelect, FormFieldTypeMultiSelect, FormFieldTypeSingleChoice, FormFieldTypeSingleChoiceSpaced, FormFieldTypeEmail, FormFieldTypeNumber, FormFieldTypeDate, FormFieldTypeTime, FormFieldTypeDateTime, FormFieldTypeFile, FormFieldTypeNPS, FormFieldTypeStarRating, FormFieldTypeCheckboxGroup, FormFieldTypeConsent, FormFieldTypeMatrix, FormFieldTypeHeading, FormFieldTypeParagraph, FormFieldTypeDivider, FormFieldTypeImage, FormFieldTypeRanking, FormFieldTypePhone, FormFieldTypeSignature, FormFieldTypeCalculated, FormFieldTypeAddress, FormFieldTypeSlider}
 
 var _FormFieldTypeNameToValueMap = map[string]FormFieldType{
-	_FormFieldTypeName[0:11]:       FormFieldTypeTextSingle,
-	_FormFieldTypeLowerName[0:11]:  FormFieldTypeTextSingle,
-	_FormFieldTypeName[11:24]:      FormFieldTypeTextMultiple,
-	_FormFieldTypeLowerName[11:24]: FormFieldTypeTextMultiple,
-	_FormFieldTypeName[24:37]:      FormFieldTypeSingleSelect,
-	_FormFieldTypeLowerName[24:37]: FormFieldTypeSingleSelect,
-	_FormFieldTypeName[37:49]:      FormFieldTypeMultiSelect,
-	_FormFieldTypeLowerName[37:49]: FormFieldTypeMultiSelect,
-	_FormFieldTypeName[49:62]:      FormFieldTypeSingleChoice,
-	_FormFieldTypeLowerName[49:62]: FormFieldTypeSingleChoice,
-	_FormFieldTypeName[62:82]:      FormFieldTypeSingleChoiceSpaced,
-	_FormFieldTypeLowerName[62:82]: FormFieldTypeSingleChoiceSpaced,
+	_FormFieldTypeName[0:11]:         FormFieldTypeTextSingle,
+	_FormFieldTypeLowerName[0:11]:    FormFieldTypeTextSingle,
+	_FormFieldTypeName[11:24]:        FormFieldTypeTextMultiple,
+	_FormFieldTypeLowerName[11:24]:   FormFieldTypeTextMultiple,
+	_FormFieldTypeName[24:37]:        FormFieldTypeSingleSelect,
+	_FormFieldTypeLowerName[24:37]:   FormFieldTypeSingleSelect,
+	_FormFieldTypeName[37:49]:        FormFieldTypeMultiSelect,
+	_FormFieldTypeLowerName[37:49]:   FormFieldTypeMultiSelect,
+	_FormFieldTypeName[49:62]:        FormFieldTypeSingleChoice,
+	_FormFieldTypeLowerName[49:62]:   FormFieldTypeSingleChoice,
+	_FormFieldTypeName[62:82]:        FormFieldTypeSingleChoiceSpaced,
+	_FormFieldTypeLowerName[62:82]:   FormFieldTypeSingleChoiceSpaced,
+	_FormFieldTypeName[82:87]:        FormFieldTypeEmail,
+	_FormFieldTypeLowerName[82:87]:   FormFieldTypeEmail,
+	_FormFieldTypeName[87:93]:        FormFieldTypeNumber,
+	_FormFieldTypeLowerName[87:93]:   FormFieldTypeNumber,
+	_FormFieldTypeName[93:97]:        FormFieldTypeDate,
+	_FormFieldTypeLowerName[93:97]:   FormFieldTypeDate,
+	_FormFieldTypeName[97:101]:       FormFieldTypeTime,
+	_FormFieldTypeLowerName[97:101]:  FormFieldTypeTime,
+	_FormFieldTypeName[101:110]:      FormFieldTypeDateTime,
+	_FormFieldTypeLowerName[101:110]: FormFieldTypeDateTime,
+	_FormFieldTypeName[110:114]:      FormFieldTypeFile,
+	_FormFieldTypeLowerName[110:114]: FormFieldTypeFile,
+	_FormFieldTypeName[114:117]:      FormFieldTypeNPS,
+	_FormFieldTypeLowerName[114:117]: FormFieldTypeNPS,
+	_FormFieldTypeName[117:128]:      FormFieldTypeStarRating,
+	_FormFieldTypeLowerName[117:128]: FormFieldTypeStarRating,
+	_FormFieldTypeName[128:142]:      FormFieldTypeCheckboxGroup,
+	_FormFieldTypeLowerName[128:142]: FormFieldTypeCheckboxGroup,
+	_FormFieldTypeName[142:149]:      FormFieldTypeConsent,
+	_FormFieldTypeLowerName[142:149]: FormFieldTypeConsent,
+	_FormFieldTypeName[149:155]:      FormFieldTypeMatrix,
+	_FormFieldTypeLowerName[149:155]: FormFieldTypeMatrix,
+	_FormFieldTypeName[155:162]:      FormFieldTypeHeading,
+	_FormFieldTypeLowerName[155:162]: FormFieldTypeHeading,
+	_FormFieldTypeName[162:171]:      FormFieldTypeParagraph,
+	_FormFieldTypeLowerName[162:171]: FormFieldTypeParagraph,
+	_FormFieldTypeName[171:178]:      FormFieldTypeDivider,
+	_FormFieldTypeLowerName[171:178]: FormFieldTypeDivider,
+	_FormFieldTypeName[178:183]:      FormFieldTypeImage,
+	_FormFieldTypeLowerName[178:183]: FormFieldTypeImage,
+	_FormFieldTypeName[183:190]:      FormFieldTypeRanking,
+	_FormFieldTypeLowerName[183:190]: FormFieldTypeRanking,
+	_FormFieldTypeName[190:195]:      FormFieldTypePhone,
+	_FormFieldTypeLowerName[190:195]: FormFieldTypePhone,
+	_FormFieldTypeName[195:204]:      FormFieldTypeSignature,
+	_FormFieldTypeLowerName[195:204]: FormFieldTypeSignature,
+	_FormFieldTypeName[204:214]:      FormFieldTypeCalculated,
+	_FormFieldTypeLowerName[204:214]: FormFieldTypeCalculated,
+	_FormFieldTypeName[214:221]:      FormFieldTypeAddress,
+	_FormFieldTypeLowerName[214:221]: FormFieldTypeAddress,
+	_FormFieldTypeName[221:227]:      FormFieldTypeSlider,
+	_FormFieldTypeLowerName[221:227]: FormFieldTypeSlider,
 }
 
 var _FormFieldTypeNames = []string{
@@ -57,6 +120,27 @@ var _FormFieldTypeNames = []string{
 	_FormFieldTypeName[37:49],
 	_FormFieldTypeName[49:62],
 	_FormFieldTypeName[62:82],
+	_FormFieldTypeName[82:87],
+	_FormFieldTypeName[87:93],
+	_FormFieldTypeName[93:97],
+	_FormFieldTypeName[97:101],
+	_FormFieldTypeName[101:110],
+	_FormFieldTypeName[110:114],
+	_FormFieldTypeName[114:117],
+	_FormFieldTypeName[117:128],
+	_FormFieldTypeName[128:142],
+	_FormFieldTypeName[142:149],
+	_FormFieldTypeName[149:155],
+	_FormFieldTypeName[155:162],
+	_FormFieldTypeName[162:171],
+	_FormFieldTypeName[171:178],
+	_FormFieldTypeName[178:183],
+	_FormFieldTypeName[183:190],
+	_FormFieldTypeName[190:195],
+	_FormFieldTypeName[195:204],
+	_FormFieldTypeName[204:214],
+	_FormFieldTypeName[214:221],
+	_FormFieldTypeName[221:227],
 }
 
 // FormFieldTypeString retrieves an enum value from the enum constants string name.
@@ -0,0 +1,211 @@
+package types
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Problem is a problem with one of a form's fields, found by [FormFields.Lint]
+type Problem struct {
+	Severity ProblemSeverity `json:"severity"` // whether the problem prevents the form from being published
+	FieldID  uuid.UUID       `json:"field_id"` // ID of the field with the problem
+	Message  string          `json:"message"`  // describes the problem to form builders
+}
+
+// Problems is a collection of problems with a form's fields
+type Problems []Problem
+
+// HasErrors returns whether any of the problems are errors, which prevent forms from being published
+func (p Problems) HasErrors() bool {
+	return slices.ContainsFunc(p, func(problem Problem) bool { return problem.Severity == ProblemSeverityError })
+}
+
+// Lint finds problems with a form's fields, in the order of the fields they belong to
+//
+// Errors are logic that targets fields that no longer exist, logic that (indirectly) targets its own field, choice
+// fields without options, options that depend on the answers to fields that no longer exist or (indirectly) on their own
+// field's answer, and calculations whose expressions are malformed or reference fields that no longer exist. Fields
+// that share a label with an earlier field are warnings.
+func (fields FormFields) Lint() (problems Problems) {
+	sorted := slices.Collect(maps.Values(fields))
+	sort.Stable(FormFieldSortByOrder(sorted))
+	labels := map[string]bool{}
+	for _, field := range sorted {
+		for _, targetID := range field.logicTargets() {
+			if _, ok := fields[targetID.String()]; !ok {
+				problems = append(problems, Problem{
+					Severity: ProblemSeverityError,
+					FieldID:  field.ID,
+					Message:  fmt.Sprintf("The logic of '%s' refers to a field that has been deleted", field.Label),
+				})
+			}
+		}
+		if cycle := fields.logicCycle(field); len(cycle) > 0 {
+			problems = append(problems, Problem{
+				Severity: ProblemSeverityError,
+				FieldID:  field.ID,
+				Message:  fmt.Sprintf("The logic of '%s' depends on itself: %s", field.Label, strings.Join(cycle, " → ")),
+			})
+		}
+		if (field.HasOptions() || field.Type == FormFieldTypeMatrix) && len(field.Options) == 0 {
+			problems = append(problems, Problem{
+				Severity: ProblemSeverityError,
+				FieldID:  field.ID,
+				Message:  fmt.Sprintf("'%s' has no options to choose from", field.Label),
+			})
+		}
+		if _, ok := field.OptionsParent(fields); field.HasOptionsParent() && !ok {
+			problems = append(problems, Problem{
+				Severity: ProblemSeverityError,
+				FieldID:  field.ID,
+				Message:  fmt.Sprintf("The options of '%s' depend on a field that has been deleted", field.Label),
+			})
+		}
+		if cycle := fields.optionsParentCycle(field); len(cycle) > 0 {
+			problems = append(problems, Problem{
+				Severity: ProblemSeverityError,
+				FieldID:  field.ID,
+				Message:  fmt.Sprintf("The options of '%s' depend on themselves: %s", field.Label, strings.Join(cycle, " → ")),
+			})
+		}
+		if field.Type == FormFieldTypeCalculated {
+			problems = append(problems, fields.lintExpression(field)...)
+		}
+		if field.Type == FormFieldTypeMatrix && len(field.Rows) == 0 {
+			problems = append(problems, Problem{
+				Severity: ProblemSeverityError,
+				FieldID:  field.ID,
+				Message:  fmt.Sprintf("'%s' has no rows to answer", field.Label),
+			})
+		}
+		label := strings.ToLower(strings.TrimSpace(field.Label))
+		if field.IsContent() || label == "" {
+			continue
+		}
+		if labels[label] {
+			problems = append(problems, Problem{
+				Severity: ProblemSeverityWarning,
+				FieldID:  field.ID,
+				Message:  fmt.Sprintf("More than one field is labeled '%s'", field.Label),
+			})
+		}
+		labels[label] = true
+	}
+	return
+}
+
+// DeleteProblems returns the errors that deleting the field with ID 'fieldID' would cause, i.e. the logic, options and
+// calculations of other fields that depend on it
+//
+// Forms with errors cannot be published, so fields are not deleted while other fields depend on them.
+func (fields FormFields) DeleteProblems(fieldID string) (problems Problems) {
+	existing := fields.Lint()
+	remaining := maps.Clone(fields)
+	delete(remaining, fieldID)
+	for _, problem := range remaining.Lint() {
+		if problem.Severity == ProblemSeverityError && !slices.Contains(existing, problem) {
+			problems = append(problems, problem)
+		}
+	}
+	return
+}
+
+// logicTargets returns the IDs of the fields targeted by the conditions of the field's logic
+func (f FormField) logicTargets() (targets []uuid.UUID) {
+	if f.Logic == nil {
+		return
+	}
+	for _, group := range f.Logic.ConditionGroups() {
+		for _, condition := range group.Conditions {
+			if !slices.Contains(targets, condition.TargetFieldID) {
+				targets = append(targets, condition.TargetFieldID)
+			}
+		}
+	}
+	return
+}
+
+// logicCycle returns the labels of the fields through which the field's logic depends on itself, starting and ending
+// with the field, or nil when its logic does not depend on itself
+func (fields FormFields) logicCycle(field FormField) (cycle []string) {
+	visited := map[uuid.UUID]bool{}
+	var path []string
+	var visit func(f FormField) bool
+	visit = func(f FormField) bool {
+		path = append(path, f.Label)
+		for _, targetID := range f.logicTargets() {
+			if targetID == field.ID {
+				path = append(path, field.Label)
+				return true
+			}
+			target, ok := fields[targetID.String()]
+			if !ok || visited[targetID] {
+				continue
+			}
+			visited[targetID] = true
+			if visit(target) {
+				return true
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+	if visit(field) {
+		return path
+	}
+	return nil
+}
+
+// optionsParentCycle returns the labels of the fields through which the options offered by the field depend on its own
+// answer, starting and ending with the field, or nil when its options do not depend on its own answer
+func (fields FormFields) optionsParentCycle(field FormField) (cycle []string) {
+	visited := map[uuid.UUID]bool{}
+	for f := field; f.HasOptionsParent() && !visited[f.ID]; {
+		visited[f.ID] = true
+		cycle = append(cycle, f.Label)
+		if *f.OptionsParentID == field.ID {
+			return append(cycle, field.Label)
+		}
+		parent, ok := f.OptionsParent(fields)
+		if !ok {
+			return nil
+		}
+		f = parent
+	}
+	return nil
+}
+
+// lintExpression finds problems with calculated fields' expressions
+func (fields FormFields) lintExpression(field FormField) (problems Problems) {
+	refs, err := field.References()
+	if err != nil {
+		return Problems{{
+			Severity: ProblemSeverityError,
+			FieldID:  field.ID,
+			Message:  fmt.Sprintf("The calculation of '%s' is not valid: %s", field.Label, err),
+		}}
+	}
+	for _, ref := range refs {
+		referenced, ok := fields[ref]
+		switch {
+		case !ok:
+			problems = append(problems, Problem{
+				Severity: ProblemSeverityError,
+				FieldID:  field.ID,
+				Message:  fmt.Sprintf("The calculation of '%s' refers to a field that has been deleted", field.Label),
+			})
+		case !referenced.IsNumeric():
+			problems = append(problems, Problem{
+				Severity: ProblemSeverityError,
+				FieldID:  field.ID,
+				Message:  fmt.Sprintf("The calculation of '%s' refers to '%s', which is not a number", field.Label, referenced.Label),
+			})
+		}
+	}
+	return
+}
@@ -0,0 +1,329 @@
+package types
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// DefaultEndMessage is shown to respondents when field logic ends the form, and no end message is configured
+const DefaultEndMessage = "There are no more questions. Please submit the form."
+
+// FieldState is the state of a field after its logic is evaluated against the values submitted to its form
+type FieldState struct {
+	Hidden   bool     // the field is not shown to respondents, because it's Hidden or its logic hides it
+	Excluded bool     // the field's logic hides it, or the form ends before it, so its value is excluded from submissions
+	Required bool     // the field must be answered
+	Disabled bool     // the field's logic makes it read-only, so respondents cannot change its value
+	Value    []string // the value set by the field's logic, or nil when its logic sets no value
+	Ends     bool     // the field's logic ends the form, so the fields after it are excluded
+	Parent   []string // the answer to the field's options parent, which determines the options offered by the field
+}
+
+// HasLogic returns whether the field's logic is completely configured, i.e. it has at least one condition with a target
+// field and trigger values
+func (f FormField) HasLogic() bool {
+	return f.Logic != nil && len(f.Logic.ConditionGroups()) > 0
+}
+
+// IsComplete returns whether the condition has a target field and the trigger values its comparator needs
+func (c FieldLogicCondition) IsComplete() bool {
+	if c.TargetFieldID == uuid.Nil {
+		return false
+	}
+	switch c.Comparator {
+	case FieldLogicComparatorIsEmpty, FieldLogicComparatorIsNotEmpty:
+		return true
+	case FieldLogicComparatorBetween:
+		return len(c.Values) > 1 && c.Values[0] != "" && c.Values[1] != ""
+	default:
+		return len(c.Values) > 0 && c.Values[0] != ""
+	}
+}
+
+// LogicComparators returns the comparators with which logic conditions may compare the field's value
+//
+// Numbers, ratings, and the options of choice fields whose DataType is numeric or rating may be compared numerically.
+func (f FormField) LogicComparators() []FieldLogicComparator {
+	if f.hasNumericAnswers() {
+		return []FieldLogicComparator{FieldLogicComparatorEqual, FieldLogicComparatorNot, FieldLogicComparatorGreaterThan,
+			FieldLogicComparatorLessThan, FieldLogicComparatorBetween, FieldLogicComparatorIsEmpty, FieldLogicComparatorIsNotEmpty}
+	}
+	return []FieldLogicComparator{FieldLogicComparatorContains, FieldLogicComparatorEqual, FieldLogicComparatorNot,
+		FieldLogicComparatorIsEmpty, FieldLogicComparatorIsNotEmpty}
+}
+
+// hasNumericAnswers returns whether the field's answers are numbers, including choice fields whose options' labels are
+// numbers
+func (f FormField) hasNumericAnswers() bool {
+	return f.IsNumeric() || f.HasOptions() && f.DataType != FormFieldDataTypeText
+}
+
+// logicNumber returns the number answered to a field with numeric answers. Choice fields' answers are the numbers in
+// the labels of their chosen options.
+//
+// ok is false when no number was answered.
+func (f FormField) logicNumber(value []string) (n float64, ok bool) {
+	if !f.HasOptions() {
+		return f.NumericValue(value)
+	}
+	for _, v := range value {
+		if n, ok = f.optionNumber(v); ok {
+			return
+		}
+	}
+	return 0, false
+}
+
+// optionNumber returns the number in the label of the field's option whose value or ID is 'value'
+//
+// ok is false when 'value' is not one of the field's options, or the option's label is not a number.
+func (f FormField) optionNumber(value string) (n float64, ok bool) {
+	for _, option := range f.Options {
+		if value != option.Value && value != option.ID.String() {
+			continue
+		}
+		if n, err := parseNumber(option.Label); err == nil {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// ConditionGroups returns the logic's groups of completely configured conditions, including the single condition of
+// logic configured before groups existed. Groups without complete conditions are omitted.
+func (l FieldLogic) ConditionGroups() (groups []FieldLogicGroup) {
+	legacy := FieldLogicCondition{TargetFieldID: l.TargetFieldID, Comparator: l.TriggerComparator, Values: l.TriggerValues}
+	if legacy.IsComplete() {
+		groups = append(groups, FieldLogicGroup{Conditions: []FieldLogicCondition{legacy}})
+	}
+	for _, group := range l.Groups {
+		conditions := slices.DeleteFunc(slices.Clone(group.Conditions), func(c FieldLogicCondition) bool { return !c.IsComplete() })
+		if len(conditions) == 0 {
+			continue
+		}
+		groups = append(groups, FieldLogicGroup{Operator: group.Operator, Conditions: conditions})
+	}
+	return
+}
+
+// normalized returns a copy of the logic in which all conditions, including the single condition of logic configured
+// before groups existed, are complete and belong to groups
+func (l FieldLogic) normalized() *FieldLogic {
+	return &FieldLogic{
+		TriggerActions: l.TriggerActions,
+		Operator:       l.Operator,
+		Groups:         l.ConditionGroups(),
+		Value:          l.Value,
+		EndMessage:     l.EndMessage,
+	}
+}
+
+// Matches returns whether the value of the condition's target field satisfies the condition's comparator and values
+//
+// Multiple values, e.g. the choices of multi-selects, are compared as a single comma-separated value, and values are
+// compared case-insensitively. Numeric comparators are never satisfied by targets without numeric answers.
+func (c FieldLogicCondition) Matches(target FormField, value []string) bool {
+	value = target.logicValue(value)
+	v := strings.Join(value, ",")
+	switch c.Comparator {
+	case FieldLogicComparatorIsEmpty:
+		return answer(value) == ""
+	case FieldLogicComparatorIsNotEmpty:
+		return answer(value) != ""
+	case FieldLogicComparatorGreaterThan, FieldLogicComparatorLessThan, FieldLogicComparatorBetween:
+		return c.matchesNumber(target, value)
+	case FieldLogicComparatorEqual:
+		for _, trigger := range c.Values {
+			if !strings.EqualFold(v, trigger) {
+				return false
+			}
+		}
+		return true
+	case FieldLogicComparatorContains:
+		for _, trigger := range c.Values {
+			if strings.Contains(strings.ToLower(v), strings.ToLower(trigger)) {
+				return true
+			}
+		}
+		return false
+	case FieldLogicComparatorNot:
+		for _, trigger := range c.Values {
+			if !strings.EqualFold(v, trigger) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// matchesNumber returns whether the numeric answer to the condition's target field satisfies the condition's numeric
+// comparator
+func (c FieldLogicCondition) matchesNumber(target FormField, value []string) bool {
+	if !target.hasNumericAnswers() {
+		return false
+	}
+	n, ok := target.logicNumber(value)
+	if !ok {
+		return false
+	}
+	bounds := []float64{}
+	for _, v := range c.Values {
+		bound, err := parseNumber(v)
+		if err != nil {
+			return false
+		}
+		bounds = append(bounds, bound)
+	}
+	switch {
+	case c.Comparator == FieldLogicComparatorGreaterThan && len(bounds) > 0:
+		return n > bounds[0]
+	case c.Comparator == FieldLogicComparatorLessThan && len(bounds) > 0:
+		return n < bounds[0]
+	case c.Comparator == FieldLogicComparatorBetween && len(bounds) > 1:
+		return n >= min(bounds[0], bounds[1]) && n <= max(bounds[0], bounds[1])
+	default:
+		return false
+	}
+}
+
+// logicValue returns the value of the field that logic conditions compare with their trigger values. Consent fields are
+// submitted alongside a hidden "false" input, so they're compared by whether they were ticked.
+func (f FormField) logicValue(value []string) []string {
+	if f.Type != FormFieldTypeConsent || value == nil {
+		return value
+	}
+	return []string{strconv.FormatBool(consented(value))}
+}
+
+// combine combines the results of conditions, or groups of conditions, with the operator
+func (o FieldLogicOperator) combine(results []bool) bool {
+	if o == FieldLogicOperatorOr {
+		return slices.Contains(results, true)
+	}
+	return !slices.Contains(results, false)
+}
+
+// EvaluateLogic computes the state of every field on a form from the values submitted to it, keyed by field ID
+//
+// Fields whose logic shows or requires them are hidden and excluded until their logic's groups of conditions are met,
+// and the other actions of fields' logic take effect when their conditions are met. When logic ends the form, the
+// fields ordered after the earliest field whose logic ends it are hidden and excluded.
+//
+// Conditions that target excluded fields are evaluated as if the target had no value, and logic that (indirectly)
+// targets its own field is ignored. Likewise, fields whose options parent is excluded offer the options of an unanswered
+// parent.
+func (fields FormFields) EvaluateLogic(values map[string][]string) (states map[string]FieldState) {
+	states = make(map[string]FieldState, len(fields))
+	evaluating := map[string]bool{}
+	var evaluate func(fieldID string) FieldState
+	evaluate = func(fieldID string) FieldState {
+		if state, ok := states[fieldID]; ok {
+			return state
+		}
+		field := fields[fieldID]
+		state := FieldState{Hidden: field.Hidden, Required: field.Required}
+		// fields being evaluated are in logic cycles, and take their static state while their cycle is evaluated
+		if evaluating[fieldID] {
+			return state
+		}
+		if !field.HasLogic() {
+			states[fieldID] = state
+			return state
+		}
+		evaluating[fieldID] = true
+		var groups []bool
+		for _, group := range field.Logic.ConditionGroups() {
+			var conditions []bool
+			for _, condition := range group.Conditions {
+				targetID := condition.TargetFieldID.String()
+				var target []string
+				targetField, ok := fields[targetID]
+				if ok && !evaluate(targetID).Excluded {
+					target = values[targetID]
+				}
+				conditions = append(conditions, condition.Matches(targetField, target))
+			}
+			groups = append(groups, group.Operator.combine(conditions))
+		}
+		delete(evaluating, fieldID)
+
+		state = field.Logic.apply(field.Logic.Operator.combine(groups), state)
+		states[fieldID] = state
+		return state
+	}
+	for fieldID := range fields {
+		evaluate(fieldID)
+	}
+
+	var end *FormField
+	for fieldID, field := range fields {
+		if states[fieldID].Ends && (end == nil || field.Order < end.Order) {
+			end = &field
+		}
+	}
+	for fieldID, field := range fields {
+		if end != nil && field.Order > end.Order {
+			states[fieldID] = FieldState{Hidden: true, Excluded: true}
+		}
+	}
+
+	for fieldID, field := range fields {
+		parent, ok := field.OptionsParent(fields)
+		if !ok || states[parent.ID.String()].Excluded {
+			continue
+		}
+		state := states[fieldID]
+		state.Parent = values[parent.ID.String()]
+		states[fieldID] = state
+	}
+	return
+}
+
+// apply applies the logic's actions to a field's state, given whether the logic's conditions are met
+func (l FieldLogic) apply(met bool, state FieldState) FieldState {
+	actions := l.TriggerActions
+	if actions.Contains(FieldLogicTriggerShow) || actions.Contains(FieldLogicTriggerRequire) {
+		if !met {
+			return FieldState{Hidden: true, Excluded: true}
+		}
+		state.Hidden = false
+		state.Required = state.Required || actions.Contains(FieldLogicTriggerRequire)
+	}
+	if !met {
+		return state
+	}
+	if actions.Contains(FieldLogicTriggerHide) {
+		state = FieldState{Hidden: true, Excluded: true}
+	}
+	if actions.Contains(FieldLogicTriggerDisable) {
+		state.Disabled = true
+		state.Required = false
+	}
+	if actions.Contains(FieldLogicTriggerSetValue) {
+		state.Value = []string{l.Value}
+	}
+	state.Ends = actions.Contains(FieldLogicTriggerEndForm)
+	return state
+}
+
+// EndFormMessage returns the message shown to respondents when the logic ends the form
+func (l FieldLogic) EndFormMessage() string {
+	if message := strings.TrimSpace(l.EndMessage); message != "" {
+		return message
+	}
+	return DefaultEndMessage
+}
+
+// WithState returns a copy of the field whose Hidden and Required settings, and offered options, reflect the field's
+// evaluated state
+func (f FormField) WithState(state FieldState) FormField {
+	f.Hidden = state.Hidden
+	f.Required = state.Required
+	f.Options = f.OfferedOptions(state.Parent)
+	return f
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+var ErrMatrixRowRequired = errors.New("Please answer every statement")
+var ErrUnknownMatrixRow = errors.New("Please answer only the statements on this form")
+
+// SortedRows returns a matrix field's rows in order
+func (f FormField) SortedRows() (sorted []Option) {
+	sorted = slices.Clone(f.Rows)
+	sort.Sort(FormFieldOptionSortNatural(sorted))
+	return
+}
+
+// validateMatrix validates the rows of matrix fields, whose values must be one of the field's options, i.e. a column of
+// the scale. Required matrix fields require an answer for every row.
+func (f FormField) validateMatrix(parts map[string][]string) (err error) {
+	for part := range parts {
+		if !slices.ContainsFunc(f.Rows, func(row Option) bool { return row.Value == part }) {
+			return ErrUnknownMatrixRow
+		}
+	}
+	for _, row := range f.SortedRows() {
+		value := slices.DeleteFunc(slices.Clone(parts[row.Value]), func(v string) bool { return strings.TrimSpace(v) == "" })
+		if len(value) == 0 {
+			if f.Required {
+				return fmt.Errorf("%w, '%s' has no answer", ErrMatrixRowRequired, row.Label)
+			}
+			continue
+		}
+		if len(value) > 1 || !allValid(f, value) {
+			return fmt.Errorf("%w for '%s'", ErrUnknownOptionProvided, row.Label)
+		}
+	}
+	return nil
+}
+
+// matrixSubmissionValue maps matrix fields' row values to the chosen option values. Unanswered rows are omitted.
+func (f FormField) matrixSubmissionValue(parts map[string][]string) any {
+	answers := map[string]string{}
+	for _, row := range f.Rows {
+		for _, v := range parts[row.Value] {
+			if strings.TrimSpace(v) != "" {
+				answers[row.Value] = v
+				break
+			}
+		}
+	}
+	return answers
+}
@@ -0,0 +1,123 @@
+package types
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+)
+
+var ErrInvalidPhoneNumber = errors.New("Please enter a valid phone number")
+
+// PhoneCountry describes how phone numbers are dialed in a country
+type PhoneCountry struct {
+	Code        string // ISO 3166-1 alpha-2 country code, e.g. "US"
+	Name        string // country name
+	CallingCode string // international calling code, e.g. "1"
+	TrunkPrefix string // prefix dialed before national numbers within the country, e.g. "0", which is not part of E.164 numbers
+	MinLength   int    // minimum length of national significant numbers
+	MaxLength   int    // maximum length of national significant numbers
+}
+
+// PhoneCountries are the countries whose phone numbers may be entered without a calling code, by setting
+// [FormField.DefaultCountry]
+//
+// Numbers entered with a calling code, e.g. "+41 44 668 18 00", are accepted for all countries.
+var PhoneCountries = []PhoneCountry{
+	{Code: "AR", Name: "Argentina", CallingCode: "54", TrunkPrefix: "0", MinLength: 10, MaxLength: 11},
+	{Code: "AT", Name: "Austria", CallingCode: "43", TrunkPrefix: "0", MinLength: 4, MaxLength: 13},
+	{Code: "AU", Name: "Australia", CallingCode: "61", TrunkPrefix: "0", MinLength: 9, MaxLength: 9},
+	{Code: "BE", Name: "Belgium", CallingCode: "32", TrunkPrefix: "0", MinLength: 8, MaxLength: 9},
+	{Code: "BR", Name: "Brazil", CallingCode: "55", TrunkPrefix: "0", MinLength: 10, MaxLength: 11},
+	{Code: "CA", Name: "Canada", CallingCode: "1", TrunkPrefix: "1", MinLength: 10, MaxLength: 10},
+	{Code: "CH", Name: "Switzerland", CallingCode: "41", TrunkPrefix: "0", MinLength: 9, MaxLength: 9},
+	{Code: "CN", Name: "China", CallingCode: "86", TrunkPrefix: "0", MinLength: 10, MaxLength: 11},
+	{Code: "DE", Name: "Germany", CallingCode: "49", TrunkPrefix: "0", MinLength: 6, MaxLength: 13},
+	{Code: "DK", Name: "Denmark", CallingCode: "45", MinLength: 8, MaxLength: 8},
+	{Code: "ES", Name: "Spain", CallingCode: "34", MinLength: 9, MaxLength: 9},
+	{Code: "FI", Name: "Finland", CallingCode: "358", TrunkPrefix: "0", MinLength: 5, MaxLength: 12},
+	{Code: "FR", Name: "France", CallingCode: "33", TrunkPrefix: "0", MinLength: 9, MaxLength: 9},
+	{Code: "GB", Name: "United Kingdom", CallingCode: "44", TrunkPrefix: "0", MinLength: 9, MaxLength: 10},
+	{Code: "IE", Name: "Ireland", CallingCode: "353", TrunkPrefix: "0", MinLength: 7, MaxLength: 9},
+	{Code: "IN", Name: "India", CallingCode: "91", TrunkPrefix: "0", MinLength: 10, MaxLength: 10},
+	{Code: "IT", Name: "Italy", CallingCode: "39", MinLength: 6, MaxLength: 11},
+	{Code: "JP", Name: "Japan", CallingCode: "81", TrunkPrefix: "0", MinLength: 9, MaxLength: 10},
+	{Code: "KR", Name: "South Korea", CallingCode: "82", TrunkPrefix: "0", MinLength: 8, MaxLength: 10},
+	{Code: "MX", Name: "Mexico", CallingCode: "52", MinLength: 10, MaxLength: 10},
+	{Code: "NL", Name: "Netherlands", CallingCode: "31", TrunkPrefix: "0", MinLength: 9, MaxLength: 9},
+	{Code: "NO", Name: "Norway", CallingCode: "47", MinLength: 8, MaxLength: 8},
+	{Code: "NZ", Name: "New Zealand", CallingCode: "64", TrunkPrefix: "0", MinLength: 8, MaxLength: 10},
+	{Code: "PL", Name: "Poland", CallingCode: "48", MinLength: 9, MaxLength: 9},
+	{Code: "PT", Name: "Portugal", CallingCode: "351", MinLength: 9, MaxLength: 9},
+	{Code: "SE", Name: "Sweden", CallingCode: "46", TrunkPrefix: "0", MinLength: 7, MaxLength: 9},
+	{Code: "SG", Name: "Singapore", CallingCode: "65", MinLength: 8, MaxLength: 8},
+	{Code: "US", Name: "United States", CallingCode: "1", TrunkPrefix: "1", MinLength: 10, MaxLength: 10},
+	{Code: "ZA", Name: "South Africa", CallingCode: "27", TrunkPrefix: "0", MinLength: 9, MaxLength: 9},
+}
+
+// phoneSeparators are characters commonly used to format phone numbers, which are ignored
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "", "/", "")
+
+// PhoneCountryByCode returns the country with the ISO 3166-1 alpha-2 code
+func PhoneCountryByCode(code string) (country PhoneCountry, ok bool) {
+	i := slices.IndexFunc(PhoneCountries, func(c PhoneCountry) bool { return strings.EqualFold(c.Code, code) })
+	if i < 0 {
+		return
+	}
+	return PhoneCountries[i], true
+}
+
+// NormalizePhoneNumber normalizes phone numbers to E.164, e.g. "+14155552671"
+//
+// Numbers without a calling code, i.e. not beginning with "+" or "00", are assumed to be dialed from defaultCountry.
+func NormalizePhoneNumber(number, defaultCountry string) (e164 string, err error) {
+	number = phoneSeparators.Replace(strings.TrimSpace(number))
+	switch {
+	case strings.HasPrefix(number, "+"):
+		number = number[1:]
+	case strings.HasPrefix(number, "00"):
+		number = number[2:]
+	default:
+		country, ok := PhoneCountryByCode(defaultCountry)
+		if !ok {
+			return "", ErrInvalidPhoneNumber
+		}
+		if country.TrunkPrefix != "" {
+			number = strings.TrimPrefix(number, country.TrunkPrefix)
+		}
+		number = country.CallingCode + number
+	}
+
+	// E.164 numbers have at most 15 digits
+	if !isDigits(number) || len(number) > 15 || number[0] == '0' {
+		return "", ErrInvalidPhoneNumber
+	}
+
+	// numbers with known calling codes must have national numbers of valid lengths, e.g. Austrian numbers may be as
+	// short as 4 digits
+	if country, ok := phoneCountryByNumber(number); ok {
+		national := strings.TrimPrefix(number, country.CallingCode)
+		if len(national) < country.MinLength || len(national) > country.MaxLength {
+			return "", ErrInvalidPhoneNumber
+		}
+	}
+
+	return "+" + number, nil
+}
+
+// phoneCountryByNumber finds the country whose calling code is the longest prefix of an international number
+func phoneCountryByNumber(number string) (country PhoneCountry, ok bool) {
+	countries := slices.Clone(PhoneCountries)
+	sort.SliceStable(countries, func(i, j int) bool { return len(countries[i].CallingCode) > len(countries[j].CallingCode) })
+	for _, c := range countries {
+		if strings.HasPrefix(number, c.CallingCode) {
+			return c, true
+		}
+	}
+	return
+}
+
+// isDigits returns whether s consists only of the digits 0-9
+func isDigits(s string) bool {
+	return s != "" && strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' }) < 0
+}
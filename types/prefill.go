@@ -0,0 +1,40 @@
+package types
+
+import (
+	"net/url"
+	"strings"
+)
+
+// ShortCodeData is data attached to short codes, from which fields may be prefilled
+//
+// Keys are the [FormField.PrefillKey]s of prefilled fields.
+type ShortCodeData map[string]string
+
+// IsPrefilled returns whether the field's value comes from the collector URL's query string or the respondent's short
+// code, rather than from the respondent
+//
+// Prefilled fields are not displayed to respondents.
+func (f FormField) IsPrefilled() bool {
+	return f.Prefill != PrefillSourceNone && strings.TrimSpace(f.PrefillKey) != ""
+}
+
+// PrefillValue returns the value of prefilled fields from the collector URL's query string, or from the data attached
+// to the respondent's short code, depending on the field's prefill source
+func (f FormField) PrefillValue(query url.Values, data ShortCodeData) (value string, ok bool) {
+	if !f.IsPrefilled() {
+		return
+	}
+	key := strings.TrimSpace(f.PrefillKey)
+	switch f.Prefill {
+	case PrefillSourceQuery:
+		if !query.Has(key) {
+			return
+		}
+		return query.Get(key), true
+	case PrefillSourceShortCode:
+		value, ok = data[key]
+		return
+	default:
+		return
+	}
+}
@@ -0,0 +1,112 @@
+// Code generated by "enumer -type PrefillSource -trimprefix PrefillSource -transform=snake -json -text"; DO NOT EDIT.
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _PrefillSourceName = "nonequeryshort_code"
+
+var _PrefillSourceIndex = [...]uint8{0, 4, 9, 19}
+
+const _PrefillSourceLowerName = "nonequeryshort_code"
+
+func (i PrefillSource) String() string {
+	if i < 0 || i >= PrefillSource(len(_PrefillSourceIndex)-1) {
+		return fmt.Sprintf("PrefillSource(%d)", i)
+	}
+	return _PrefillSourceName[_PrefillSourceIndex[i]:_PrefillSourceIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _PrefillSourceNoOp() {
+	var x [1]struct{}
+	_ = x[PrefillSourceNone-(0)]
+	_ = x[PrefillSourceQuery-(1)]
+	_ = x[PrefillSourceShortCode-(2)]
+}
+
+var _PrefillSourceValues = []PrefillSource{PrefillSourceNone, PrefillSourceQuery, PrefillSourceShortCode}
+
+var _PrefillSourceNameToValueMap = map[string]PrefillSource{
+	_PrefillSourceName[0:4]:       PrefillSourceNone,
+	_PrefillSourceLowerName[0:4]:  PrefillSourceNone,
+	_PrefillSourceName[4:9]:       PrefillSourceQuery,
+	_PrefillSourceLowerName[4:9]:  PrefillSourceQuery,
+	_PrefillSourceName[9:19]:      PrefillSourceShortCode,
+	_PrefillSourceLowerName[9:19]: PrefillSourceShortCode,
+}
+
+var _PrefillSourceNames = []string{
+	_PrefillSourceName[0:4],
+	_PrefillSourceName[4:9],
+	_PrefillSourceName[9:19],
+}
+
+// PrefillSourceString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func PrefillSourceString(s string) (PrefillSource, error) {
+	if val, ok := _PrefillSourceNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _PrefillSourceNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to PrefillSource values", s)
+}
+
+// PrefillSourceValues returns all values of the enum
+func PrefillSourceValues() []PrefillSource {
+	return _PrefillSourceValues
+}
+
+// PrefillSourceStrings returns a slice of all String values of the enum
+func PrefillSourceStrings() []string {
+	strs := make([]string, len(_PrefillSourceNames))
+	copy(strs, _PrefillSourceNames)
+	return strs
+}
+
+// IsAPrefillSource returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i PrefillSource) IsAPrefillSource() bool {
+	for _, v := range _PrefillSourceValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for PrefillSource
+func (i PrefillSource) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for PrefillSource
+func (i *PrefillSource) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("PrefillSource should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = PrefillSourceString(s)
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for PrefillSource
+func (i PrefillSource) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for PrefillSource
+func (i *PrefillSource) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = PrefillSourceString(string(text))
+	return err
+}
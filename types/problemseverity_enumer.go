@@ -0,0 +1,108 @@
+// Code generated by "enumer -type ProblemSeverity -trimprefix ProblemSeverity -transform=snake -json -text"; DO NOT EDIT.
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _ProblemSeverityName = "errorwarning"
+
+var _ProblemSeverityIndex = [...]uint8{0, 5, 12}
+
+const _ProblemSeverityLowerName = "errorwarning"
+
+func (i ProblemSeverity) String() string {
+	if i < 0 || i >= ProblemSeverity(len(_ProblemSeverityIndex)-1) {
+		return fmt.Sprintf("ProblemSeverity(%d)", i)
+	}
+	return _ProblemSeverityName[_ProblemSeverityIndex[i]:_ProblemSeverityIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _ProblemSeverityNoOp() {
+	var x [1]struct{}
+	_ = x[ProblemSeverityError-(0)]
+	_ = x[ProblemSeverityWarning-(1)]
+}
+
+var _ProblemSeverityValues = []ProblemSeverity{ProblemSeverityError, ProblemSeverityWarning}
+
+var _ProblemSeverityNameToValueMap = map[string]ProblemSeverity{
+	_ProblemSeverityName[0:5]:       ProblemSeverityError,
+	_ProblemSeverityLowerName[0:5]:  ProblemSeverityError,
+	_ProblemSeverityName[5:12]:      ProblemSeverityWarning,
+	_ProblemSeverityLowerName[5:12]: ProblemSeverityWarning,
+}
+
+var _ProblemSeverityNames = []string{
+	_ProblemSeverityName[0:5],
+	_ProblemSeverityName[5:12],
+}
+
+// ProblemSeverityString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func ProblemSeverityString(s string) (ProblemSeverity, error) {
+	if val, ok := _ProblemSeverityNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _ProblemSeverityNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to ProblemSeverity values", s)
+}
+
+// ProblemSeverityValues returns all values of the enum
+func ProblemSeverityValues() []ProblemSeverity {
+	return _ProblemSeverityValues
+}
+
+// ProblemSeverityStrings returns a slice of all String values of the enum
+func ProblemSeverityStrings() []string {
+	strs := make([]string, len(_ProblemSeverityNames))
+	copy(strs, _ProblemSeverityNames)
+	return strs
+}
+
+// IsAProblemSeverity returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i ProblemSeverity) IsAProblemSeverity() bool {
+	for _, v := range _ProblemSeverityValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for ProblemSeverity
+func (i ProblemSeverity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for ProblemSeverity
+func (i *ProblemSeverity) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("ProblemSeverity should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = ProblemSeverityString(s)
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for ProblemSeverity
+func (i ProblemSeverity) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for ProblemSeverity
+func (i *ProblemSeverity) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = ProblemSeverityString(string(text))
+	return err
+}
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Rating scales
+const (
+	NPSMin           = 0  // the lowest score on net promoter score scales
+	NPSMax           = 10 // the highest score on net promoter score scales
+	DefaultStarCount = 5  // the number of stars on star rating scales when not configured
+	MaxStarCount     = 10 // the largest number of stars that star rating scales may have
+)
+
+var ErrInvalidRating = errors.New("Please choose a rating")
+
+// IsRating returns whether the field is a rating field
+func (f FormField) IsRating() bool {
+	return f.Type == FormFieldTypeNPS || f.Type == FormFieldTypeStarRating
+}
+
+// RatingScale returns the lowest and highest ratings that may be submitted to rating fields
+//
+// Star rating fields are configured with the number of stars in [FormField.Max]
+func (f FormField) RatingScale() (low, high int) {
+	switch f.Type {
+	case FormFieldTypeNPS:
+		return NPSMin, NPSMax
+	default:
+		high = DefaultStarCount
+		if f.Max != nil && *f.Max >= 1 {
+			high = min(int(*f.Max), MaxStarCount)
+		}
+		return 1, high
+	}
+}
+
+// Ratings returns every rating that may be submitted to rating fields, from lowest to highest
+func (f FormField) Ratings() (ratings []int) {
+	low, high := f.RatingScale()
+	for r := low; r <= high; r++ {
+		ratings = append(ratings, r)
+	}
+	return
+}
+
+// parseRating parses ratings, ensuring they're within the field's rating scale
+func (f FormField) parseRating(value string) (rating int, err error) {
+	rating, err = strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, ErrInvalidRating
+	}
+	low, high := f.RatingScale()
+	if rating < low || rating > high {
+		return 0, ErrInvalidRating
+	}
+	return
+}
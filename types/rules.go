@@ -0,0 +1,70 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var ErrTooShort = errors.New("This answer is too short")
+var ErrTooLong = errors.New("This answer is too long")
+var ErrPatternMismatch = errors.New("This answer is not in the expected format")
+var ErrInvalidPattern = errors.New("invalid pattern")
+
+// ruleError reports violations of validation rules with a custom message, while still wrapping the rule's error, e.g.
+// [ErrTooShort]
+type ruleError struct {
+	err     error
+	message string
+}
+
+func (e ruleError) Error() string { return e.message }
+func (e ruleError) Unwrap() error { return e.err }
+
+// HasValidationRules returns whether the field may be configured with length and pattern rules
+func (f FormField) HasValidationRules() bool {
+	switch f.Type {
+	case FormFieldTypeTextSingle, FormFieldTypeTextMultiple, FormFieldTypeEmail:
+		return true
+	default:
+		return false
+	}
+}
+
+// PatternRegexp compiles the field's pattern, which must match values in their entirety, like HTML pattern attributes
+//
+// re is nil when the field has no pattern.
+func (f FormField) PatternRegexp() (re *regexp.Regexp, err error) {
+	if f.Pattern == "" {
+		return nil, nil
+	}
+	re, err = regexp.Compile(fmt.Sprintf("^(?:%s)$", f.Pattern))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPattern, strings.TrimPrefix(err.Error(), "error parsing regexp: "))
+	}
+	return
+}
+
+// validateRules validates that a value satisfies the field's length and pattern rules
+//
+// Violations are reported with the field's ValidationMessage, when configured. Invalid patterns are ignored, as they
+// are by browsers.
+func (f FormField) validateRules(value string) (err error) {
+	length := utf8.RuneCountInString(value)
+	switch {
+	case f.MinLength > 0 && length < f.MinLength:
+		err = fmt.Errorf("%w, it must be at least %d characters", ErrTooShort, f.MinLength)
+	case f.MaxLength > 0 && length > f.MaxLength:
+		err = fmt.Errorf("%w, it must be at most %d characters", ErrTooLong, f.MaxLength)
+	default:
+		if re, reErr := f.PatternRegexp(); reErr == nil && re != nil && !re.MatchString(value) {
+			err = ErrPatternMismatch
+		}
+	}
+	if err != nil && strings.TrimSpace(f.ValidationMessage) != "" {
+		return ruleError{err: err, message: strings.TrimSpace(f.ValidationMessage)}
+	}
+	return
+}
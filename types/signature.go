@@ -0,0 +1,36 @@
+package types
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxSignatureSize is the maximum size in bytes of signatures drawn on signature fields
+const MaxSignatureSize = 1 << 20
+
+// signatureDataURLPrefix prefixes the PNG data URLs submitted by signature fields
+const signatureDataURLPrefix = "data:image/png;base64,"
+
+// pngSignature are the first bytes of all PNG images
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+var ErrInvalidSignature = errors.New("Please sign again, this signature could not be read")
+
+// DecodeSignature decodes the PNG data URLs submitted by signature fields to PNG images
+func DecodeSignature(value string) (png []byte, err error) {
+	encoded, ok := strings.CutPrefix(strings.TrimSpace(value), signatureDataURLPrefix)
+	if !ok {
+		return nil, ErrInvalidSignature
+	}
+	if base64.StdEncoding.DecodedLen(len(encoded)) > MaxSignatureSize {
+		return nil, fmt.Errorf("%w, it is too large", ErrInvalidSignature)
+	}
+	png, err = base64.StdEncoding.DecodeString(encoded)
+	if err != nil || !bytes.HasPrefix(png, pngSignature) {
+		return nil, ErrInvalidSignature
+	}
+	return png, nil
+}
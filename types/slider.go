@@ -0,0 +1,33 @@
+package types
+
+// Slider ranges, used when sliders' [FormField.Min], [FormField.Max] or [FormField.Step] are not configured
+const (
+	DefaultSliderMin  = 0.0
+	DefaultSliderMax  = 100.0
+	DefaultSliderStep = 1.0
+)
+
+// SliderRange returns the lowest and highest values of slider fields, and the step between values
+func (f FormField) SliderRange() (low, high, step float64) {
+	low, high, step = DefaultSliderMin, DefaultSliderMax, DefaultSliderStep
+	if f.Min != nil {
+		low = *f.Min
+	}
+	if f.Max != nil {
+		high = *f.Max
+	}
+	if high <= low {
+		low, high = DefaultSliderMin, DefaultSliderMax
+	}
+	if f.Step != nil && *f.Step > 0 {
+		step = *f.Step
+	}
+	return
+}
+
+// validateSlider validates that a value is a number within the slider's range, in increments of its step
+func (f FormField) validateSlider(value string) (err error) {
+	low, high, step := f.SliderRange()
+	f.Min, f.Max, f.Step = &low, &high, &step
+	return f.validateNumber(value)
+}
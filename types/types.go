@@ -3,9 +3,15 @@ package types
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"math/rand/v2"
+	"net/mail"
 	"slices"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -13,6 +19,15 @@ import (
 // ErrRequiredNoValueProvided is a form validation error for required fields missing values
 var ErrRequiredNoValueProvided = errors.New("This field is required")
 var ErrUnknownOptionProvided = errors.New("This field is required, please choose a valid option")
+var ErrOptionNotOffered = errors.New("This option is not available for your other answers")
+var ErrInvalidEmail = errors.New("Please enter a valid email address")
+var ErrTooFewChoices = errors.New("Too few options were chosen")
+var ErrTooManyChoices = errors.New("Too many options were chosen")
+var ErrInvalidRanking = errors.New("Please rank every option exactly once")
+var ErrNotANumber = errors.New("Please enter a number")
+var ErrNotAnInteger = errors.New("Please enter a whole number")
+var ErrNumberOutOfRange = errors.New("This number is out of range")
+var ErrNumberStep = errors.New("This number is not an allowed value")
 
 // ValidationErrors is a mapping of form field IDs to the errors validating values submitted to those fields
 type ValidationErrors map[string]error
@@ -33,6 +48,27 @@ const (
 	FormFieldTypeMultiSelect                             // multi-select dropdown
 	FormFieldTypeSingleChoice                            // nicely styled radio buttons
 	FormFieldTypeSingleChoiceSpaced                      // nicely styled radio buttons, spaced out
+	FormFieldTypeEmail                                   // email address
+	FormFieldTypeNumber                                  // numeric input, optionally constrained by min, max and step
+	FormFieldTypeDate                                    // date picker
+	FormFieldTypeTime                                    // time picker
+	FormFieldTypeDateTime                                // date and time picker
+	FormFieldTypeFile                                    // file upload
+	FormFieldTypeNPS                                     // net promoter score, a 0-10 rating scale
+	FormFieldTypeStarRating                              // rating scale of N stars
+	FormFieldTypeCheckboxGroup                           // nicely styled checkboxes, allowing multiple choices
+	FormFieldTypeConsent                                 // a single checkbox or toggle, e.g. for accepting terms
+	FormFieldTypeMatrix                                  // grid of statements (rows) answered on a shared scale (options)
+	FormFieldTypeHeading                                 // display-only section heading
+	FormFieldTypeParagraph                               // display-only paragraph of markdown
+	FormFieldTypeDivider                                 // display-only horizontal divider
+	FormFieldTypeImage                                   // display-only image
+	FormFieldTypeRanking                                 // drag-to-order ranking of options
+	FormFieldTypePhone                                   // phone number, normalized to E.164
+	FormFieldTypeSignature                               // signature drawn on a canvas, stored as a PNG image
+	FormFieldTypeCalculated                              // read-only number calculated from other fields' values
+	FormFieldTypeAddress                                 // street address, entered as separate parts
+	FormFieldTypeSlider                                  // range slider between min and max, in increments of step
 )
 
 // FormFieldDataType enum enumerates all possible data types for form fields
@@ -58,9 +94,14 @@ func FormFieldDataTypes() []FormFieldDataType {
 type FieldLogicComparator int
 
 const (
-	FieldLogicComparatorEqual    FieldLogicComparator = iota // target field value is equal to the subject value
-	FieldLogicComparatorContains                             // target field value contains the subject value
-	FieldLogicComparatorNot                                  // target field value is "not" the subject value
+	FieldLogicComparatorEqual       FieldLogicComparator = iota // target field value is equal to the subject value
+	FieldLogicComparatorContains                                // target field value contains the subject value
+	FieldLogicComparatorNot                                     // target field value is "not" the subject value
+	FieldLogicComparatorGreaterThan                             // target field value is a number greater than the subject value
+	FieldLogicComparatorLessThan                                // target field value is a number less than the subject value
+	FieldLogicComparatorBetween                                 // target field value is a number between the two subject values, inclusive
+	FieldLogicComparatorIsEmpty                                 // target field has no value
+	FieldLogicComparatorIsNotEmpty                              // target field has a value
 )
 
 // FormFieldOptionOrder enum enumerates all possible ways to order FieldOptions
@@ -79,8 +120,55 @@ const (
 type FieldLogicTriggerAction int
 
 const (
-	FieldLogicTriggerShow    FieldLogicTriggerAction = iota // make the field visible to the user
-	FieldLogicTriggerRequire                                // require the user to enter a value
+	FieldLogicTriggerShow     FieldLogicTriggerAction = iota // make the field visible to the user
+	FieldLogicTriggerRequire                                 // require the user to enter a value
+	FieldLogicTriggerHide                                    // hide the field from the user, excluding its value
+	FieldLogicTriggerDisable                                 // make the field read-only
+	FieldLogicTriggerSetValue                                // set the field's value to the logic's Value
+	FieldLogicTriggerEndForm                                 // end the form after the field, showing the logic's EndMessage
+)
+
+// FieldLogicOperator enum enumerates the ways field logic conditions are combined
+//
+//go:generate enumer -type FieldLogicOperator -trimprefix FieldLogicOperator -transform=snake -json -text
+type FieldLogicOperator int
+
+const (
+	FieldLogicOperatorAnd FieldLogicOperator = iota // every condition must be met
+	FieldLogicOperatorOr                            // at least one condition must be met
+)
+
+// PrefillSource enum enumerates all possible sources of prefilled field values
+//
+//go:generate enumer -type PrefillSource -trimprefix PrefillSource -transform=snake -json -text
+type PrefillSource int
+
+const (
+	PrefillSourceNone      PrefillSource = iota // field values are entered by respondents
+	PrefillSourceQuery                          // field values come from the collector URL's query string
+	PrefillSourceShortCode                      // field values come from the data attached to respondents' short codes
+)
+
+// CrossFieldRuleKind enum enumerates all possible rules relating fields' values to the values of other fields
+//
+//go:generate enumer -type CrossFieldRuleKind -trimprefix CrossFieldRuleKind -transform=snake -json -text
+type CrossFieldRuleKind int
+
+const (
+	CrossFieldRuleKindEqual      CrossFieldRuleKind = iota // the field's value equals the other field's, e.g. confirming email addresses
+	CrossFieldRuleKindAfter                                // the field's value is after the other field's, e.g. end dates after start dates
+	CrossFieldRuleKindBefore                               // the field's value is before the other field's
+	CrossFieldRuleKindAtLeastOne                           // at least one of the field and the other fields is answered
+)
+
+// ProblemSeverity enum enumerates the severities of problems found in forms by [FormFields.Lint]
+//
+//go:generate enumer -type ProblemSeverity -trimprefix ProblemSeverity -transform=snake -json -text
+type ProblemSeverity int
+
+const (
+	ProblemSeverityError   ProblemSeverity = iota // the form is broken, and may not be published
+	ProblemSeverityWarning                        // the form is likely to confuse respondents, but may be published
 )
 
 // FormFields is a collection of form fields associated with a Form
@@ -98,18 +186,44 @@ type FieldOptions []Option
 
 // FormField is a field associated with a form
 type FormField struct {
-	ID           uuid.UUID            `json:"id"`            // field's unique id
-	Order        int                  `json:"order"`         // order in which the field appears on forms
-	Label        string               `json:"label"`         // field's label (name)
-	Logic        *FieldLogic          `json:"logic"`         // UI logic for this field
-	Options      FieldOptions         `json:"options"`       // single/multi-select options
-	OptionLabels []string             `json:"option_labels"` // option labels are shown below [types.FormFieldTypeSingleChoice] options
-	OptionOrder  FormFieldOptionOrder `json:"option_order"`  // the order in which options appear to viewers
-	Placeholder  string               `json:"placeholder"`   // placeholder value
-	Required     bool                 `json:"required"`      // whether the field is required
-	Hidden       bool                 `json:"hidden"`        // whether the field is hidden
-	Type         FormFieldType        `json:"type"`          // field type
-	DataType     FormFieldDataType    `json:"data_type"`     // the data type form submissions to this field
+	ID                uuid.UUID            `json:"id"`                           // field's unique id
+	Order             int                  `json:"order"`                        // order in which the field appears on forms
+	Label             string               `json:"label"`                        // field's label (name)
+	Logic             *FieldLogic          `json:"logic"`                        // UI logic for this field
+	Options           FieldOptions         `json:"options"`                      // single/multi-select options
+	OptionLabels      []string             `json:"option_labels"`                // option labels are shown below [types.FormFieldTypeSingleChoice] options, rating scales and sliders
+	OptionOrder       FormFieldOptionOrder `json:"option_order"`                 // the order in which options appear to viewers
+	Placeholder       string               `json:"placeholder"`                  // placeholder value
+	Required          bool                 `json:"required"`                     // whether the field is required
+	Hidden            bool                 `json:"hidden"`                       // whether the field is hidden
+	Type              FormFieldType        `json:"type"`                         // field type
+	DataType          FormFieldDataType    `json:"data_type"`                    // the data type form submissions to this field
+	Min               *float64             `json:"min,omitempty"`                // minimum value for numeric fields and sliders
+	Max               *float64             `json:"max,omitempty"`                // maximum value for numeric fields and sliders, and the number of stars for star rating fields
+	Step              *float64             `json:"step,omitempty"`               // numeric field values must be a multiple of step, offset by Min
+	IntegerOnly       bool                 `json:"integer_only,omitempty"`       // numeric fields only accept whole numbers
+	Earliest          string               `json:"earliest,omitempty"`           // earliest date/time accepted by date and time fields, or [DateBoundNow]
+	Latest            string               `json:"latest,omitempty"`             // latest date/time accepted by date and time fields, or [DateBoundNow]
+	MimeTypes         []string             `json:"mime_types,omitempty"`         // MIME types accepted by file fields, e.g. "application/pdf" or "image/*"
+	MaxFileSize       int64                `json:"max_file_size,omitempty"`      // maximum size in bytes of files uploaded to file fields
+	MinChoices        int                  `json:"min_choices,omitempty"`        // minimum number of options that must be chosen for checkbox groups, when answered
+	MaxChoices        int                  `json:"max_choices,omitempty"`        // maximum number of options that may be chosen for checkbox groups
+	Toggle            bool                 `json:"toggle,omitempty"`             // consent fields are displayed as yes/no toggles rather than checkboxes
+	Rows              FieldOptions         `json:"rows,omitempty"`               // statements answered by matrix fields, using Options as the scale
+	Content           string               `json:"content,omitempty"`            // markdown content of paragraph blocks
+	ImageURL          string               `json:"image_url,omitempty"`          // URL of the image shown by image blocks, whose Label is the image's alt text
+	DefaultCountry    string               `json:"default_country,omitempty"`    // ISO 3166-1 alpha-2 code of the country assumed for phone numbers entered without a calling code
+	Prefill           PrefillSource        `json:"prefill,omitempty"`            // source of prefilled field values, which are not entered by respondents
+	PrefillKey        string               `json:"prefill_key,omitempty"`        // query parameter or short code data key of prefilled field values
+	Expression        string               `json:"expression,omitempty"`         // arithmetic expression computing calculated fields' values, e.g. "{<field id>} * 2"
+	RequiredParts     []string             `json:"required_parts,omitempty"`     // parts of address fields that must be answered, e.g. [AddressPartCity]
+	MinLength         int                  `json:"min_length,omitempty"`         // minimum number of characters in text and email answers
+	MaxLength         int                  `json:"max_length,omitempty"`         // maximum number of characters in text and email answers
+	Pattern           string               `json:"pattern,omitempty"`            // regular expression that text and email answers must match in their entirety
+	ValidationMessage string               `json:"validation_message,omitempty"` // message shown to respondents when answers violate MinLength, MaxLength or Pattern
+	Validators        []string             `json:"validators,omitempty"`         // names of the host's custom validators that answers must pass, see [frm.Validator]
+	CrossFieldRules   []CrossFieldRule     `json:"cross_field_rules,omitempty"`  // rules relating the field's value to other fields' values
+	OptionsParentID   *uuid.UUID           `json:"options_parent_id,omitempty"`  // ID of the field whose answer determines the options offered by choice fields, see [Option.ParentValues]
 }
 
 // FormFieldSubmission is a form submission for a particular form field. Form submissions consists of one or more form field submission
@@ -125,11 +239,32 @@ type FormFieldSubmission struct {
 }
 
 // FieldLogic defines logic associated with a field
+//
+// Logic is made of groups of conditions. Conditions within each group are combined with the group's operator, and
+// groups are combined with the logic's Operator. Logic configured before groups existed has a single condition, which
+// is defined by TargetFieldID, TriggerComparator and TriggerValues, and is treated as a group of its own.
 type FieldLogic struct {
-	TargetFieldID     uuid.UUID                `json:"target_field_id"`  // ID of the field to monitor for logic evaluation
-	TriggerComparator FieldLogicComparator     `json:"field_comparator"` // comparator to use evaluating target field's value with trigger values
-	TriggerValues     []string                 `json:"trigger_values"`   // values that target field's value is compared with
-	TriggerActions    FieldLogicTriggerActions `json:"actions"`          // actions to take when the field comparator evaluates true
+	TargetFieldID     uuid.UUID                `json:"target_field_id"`       // ID of the field to monitor for logic evaluation
+	TriggerComparator FieldLogicComparator     `json:"field_comparator"`      // comparator to use evaluating target field's value with trigger values
+	TriggerValues     []string                 `json:"trigger_values"`        // values that target field's value is compared with
+	TriggerActions    FieldLogicTriggerActions `json:"actions"`               // actions to take when the field comparator evaluates true
+	Operator          FieldLogicOperator       `json:"operator"`              // operator combining the results of Groups
+	Groups            []FieldLogicGroup        `json:"groups,omitempty"`      // groups of conditions that trigger the actions
+	Value             string                   `json:"value,omitempty"`       // the value set by FieldLogicTriggerSetValue
+	EndMessage        string                   `json:"end_message,omitempty"` // the message shown by FieldLogicTriggerEndForm
+}
+
+// FieldLogicGroup is a group of field logic conditions
+type FieldLogicGroup struct {
+	Operator   FieldLogicOperator    `json:"operator"`   // operator combining the results of Conditions
+	Conditions []FieldLogicCondition `json:"conditions"` // conditions in the group
+}
+
+// FieldLogicCondition compares the value of a target field with trigger values
+type FieldLogicCondition struct {
+	TargetFieldID uuid.UUID            `json:"target_field_id"` // ID of the field whose value is compared
+	Comparator    FieldLogicComparator `json:"comparator"`      // comparator to use evaluating target field's value with trigger values
+	Values        []string             `json:"values"`          // values that target field's value is compared with
 }
 
 // FieldLogicTriggerActions is a collection of field logic trigger actions
@@ -147,12 +282,13 @@ func (f FieldLogicTriggerActions) Contains(a FieldLogicTriggerAction) bool {
 
 // Option is a select option (single and multi)
 type Option struct {
-	ID       uuid.UUID `json:"id"`
-	Value    string    `json:"value"`
-	Label    string    `json:"label"`
-	Order    int       `json:"order"`
-	Selected bool      `json:"-"`
-	Disabled bool      `json:"-"`
+	ID           uuid.UUID `json:"id"`
+	Value        string    `json:"value"`
+	Label        string    `json:"label"`
+	Order        int       `json:"order"`
+	ParentValues []string  `json:"parent_values,omitempty"` // answers to the field's options parent for which the option is offered, see [FormField.OptionsParentID]
+	Selected     bool      `json:"-"`
+	Disabled     bool      `json:"-"`
 }
 
 // FormFieldSortByOrder implements sort.Interface for []FormField based on
@@ -178,13 +314,22 @@ func (f FormFieldOptionSortRand) Swap(i, j int)      { f[i], f[j] = f[j], f[i] }
 func (f FormFieldOptionSortRand) Less(i, j int) bool { return rand.Int64()%2 == 0 }
 
 // Validate validates values submitted to a form field
+//
+// Fields whose options depend on another field's answer are validated as though that answer were blank, so options
+// that are conditioned on their options parent's answer are rejected. Use [FormField.ValidateWithParent] to validate
+// them against the answer.
 func (f FormField) Validate(value []string) (err error) {
+	return f.ValidateWithParent(value, nil)
+}
+
+// ValidateWithParent validates values submitted to a form field whose options parent is answered with 'parent'
+func (f FormField) ValidateWithParent(value, parent []string) (err error) {
 	if f.Required {
 		if len(value) == 0 {
 			return ErrRequiredNoValueProvided
 		}
 		for _, ffv := range value {
-			if ffv == "" {
+			if strings.TrimSpace(ffv) == "" {
 				return ErrRequiredNoValueProvided
 			}
 		}
@@ -192,7 +337,7 @@ func (f FormField) Validate(value []string) (err error) {
 
 	switch f.Type {
 	// ensure that the provided value is one of this field's available options
-	case FormFieldTypeSingleSelect, FormFieldTypeMultiSelect, FormFieldTypeSingleChoice, FormFieldTypeSingleChoiceSpaced:
+	case FormFieldTypeSingleSelect, FormFieldTypeMultiSelect, FormFieldTypeSingleChoice, FormFieldTypeSingleChoiceSpaced, FormFieldTypeCheckboxGroup:
 		// Choices-js causes fields to be submitted with am empty value, rather than excluding it. This is a bit hacky.
 		if !f.Required && len(value) == 1 && value[0] == "" {
 			return nil
@@ -201,12 +346,249 @@ func (f FormField) Validate(value []string) (err error) {
 		if !allValid(f, value) {
 			return ErrUnknownOptionProvided
 		}
+		if !allOffered(f, value, parent) {
+			return ErrOptionNotOffered
+		}
+		if f.Type == FormFieldTypeCheckboxGroup {
+			return f.validateChoiceCount(value)
+		}
+		return nil
+	case FormFieldTypeRanking:
+		return f.validateRanking(value)
+	case FormFieldTypeTextSingle, FormFieldTypeTextMultiple:
+		for _, ffv := range value {
+			if strings.TrimSpace(ffv) == "" {
+				continue
+			}
+			if err = f.validateRules(ffv); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormFieldTypeEmail:
+		for _, ffv := range value {
+			if strings.TrimSpace(ffv) == "" {
+				continue
+			}
+			if _, ok := normalizeEmail(ffv); !ok {
+				return ErrInvalidEmail
+			}
+			if err = f.validateRules(ffv); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormFieldTypeNumber:
+		for _, ffv := range value {
+			if strings.TrimSpace(ffv) == "" {
+				continue
+			}
+			if err = f.validateNumber(ffv); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormFieldTypeSlider:
+		for _, ffv := range value {
+			if strings.TrimSpace(ffv) == "" {
+				continue
+			}
+			if err = f.validateSlider(ffv); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormFieldTypePhone:
+		for _, ffv := range value {
+			if strings.TrimSpace(ffv) == "" {
+				continue
+			}
+			if _, err = NormalizePhoneNumber(ffv, f.DefaultCountry); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormFieldTypeSignature:
+		for _, ffv := range value {
+			if strings.TrimSpace(ffv) == "" {
+				continue
+			}
+			if _, err = DecodeSignature(ffv); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormFieldTypeConsent:
+		if f.Required && !consented(value) {
+			return ErrConsentRequired
+		}
+		return nil
+	case FormFieldTypeNPS, FormFieldTypeStarRating:
+		for _, ffv := range value {
+			if strings.TrimSpace(ffv) == "" {
+				continue
+			}
+			if _, err = f.parseRating(ffv); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormFieldTypeDate, FormFieldTypeTime, FormFieldTypeDateTime:
+		now := time.Now()
+		for _, ffv := range value {
+			if strings.TrimSpace(ffv) == "" {
+				continue
+			}
+			if err = f.validateTemporal(ffv, now); err != nil {
+				return err
+			}
+		}
 		return nil
 	default:
 		return nil
 	}
 }
 
+// validateRanking validates that rankings contain every one of the field's options exactly once
+//
+// Rankings are only submitted once respondents rank their options, so rankings without values are unanswered.
+func (f FormField) validateRanking(value []string) (err error) {
+	if len(value) == 0 {
+		return nil
+	}
+	if len(value) != len(f.Options) {
+		return ErrInvalidRanking
+	}
+	ranked := map[string]bool{}
+	for _, v := range value {
+		if ranked[v] {
+			return ErrInvalidRanking
+		}
+		ranked[v] = true
+	}
+	if !allValid(f, value) {
+		return ErrInvalidRanking
+	}
+	return nil
+}
+
+// validateChoiceCount validates the number of options chosen for checkbox groups
+func (f FormField) validateChoiceCount(value []string) (err error) {
+	chosen := 0
+	for _, v := range value {
+		if v != "" {
+			chosen++
+		}
+	}
+	if chosen == 0 {
+		return nil
+	}
+	if f.MinChoices > 0 && chosen < f.MinChoices {
+		return fmt.Errorf("%w, choose at least %d", ErrTooFewChoices, f.MinChoices)
+	}
+	if f.MaxChoices > 0 && chosen > f.MaxChoices {
+		return fmt.Errorf("%w, choose at most %d", ErrTooManyChoices, f.MaxChoices)
+	}
+	return nil
+}
+
+// validateNumber validates that a value is a number satisfying the field's numeric constraints
+func (f FormField) validateNumber(value string) (err error) {
+	n, err := parseNumber(value)
+	if err != nil {
+		return ErrNotANumber
+	}
+	if f.IntegerOnly && n != math.Trunc(n) {
+		return ErrNotAnInteger
+	}
+	if f.Min != nil && n < *f.Min {
+		return fmt.Errorf("%w, it must be at least %s", ErrNumberOutOfRange, FormatNumber(f.Min))
+	}
+	if f.Max != nil && n > *f.Max {
+		return fmt.Errorf("%w, it must be at most %s", ErrNumberOutOfRange, FormatNumber(f.Max))
+	}
+	if f.Step != nil && *f.Step > 0 {
+		base := 0.0
+		if f.Min != nil {
+			base = *f.Min
+		}
+		steps := (n - base) / *f.Step
+		if math.Abs(steps-math.Round(steps)) > 1e-9 {
+			return fmt.Errorf("%w, it must be in increments of %s", ErrNumberStep, FormatNumber(f.Step))
+		}
+	}
+	return nil
+}
+
+// HasOptions returns whether the field's values are chosen from its [FormField.Options]
+func (f FormField) HasOptions() bool {
+	switch f.Type {
+	case FormFieldTypeSingleSelect, FormFieldTypeMultiSelect, FormFieldTypeSingleChoice, FormFieldTypeSingleChoiceSpaced, FormFieldTypeCheckboxGroup,
+		FormFieldTypeRanking:
+		return true
+	default:
+		return false
+	}
+}
+
+// SubmissionValue converts values submitted to a form field to the value stored in [FormFieldSubmission.Value]
+//
+// Values should be validated with [FormField.Validate] before being converted.
+func (f FormField) SubmissionValue(value []string) any {
+	switch f.Type {
+	case FormFieldTypeEmail:
+		normalized := make([]string, 0, len(value))
+		for _, v := range value {
+			if email, ok := normalizeEmail(v); ok {
+				v = email
+			}
+			normalized = append(normalized, v)
+		}
+		return normalized
+	case FormFieldTypeNumber, FormFieldTypeSlider:
+		for _, v := range value {
+			n, err := parseNumber(v)
+			if err != nil {
+				continue
+			}
+			if f.IntegerOnly {
+				return int64(n)
+			}
+			return n
+		}
+		return nil
+	case FormFieldTypeDate, FormFieldTypeTime, FormFieldTypeDateTime:
+		return f.temporalSubmissionValue(value)
+	case FormFieldTypeConsent:
+		return consented(value)
+	case FormFieldTypeFile, FormFieldTypeSignature:
+		// files and signatures are stored in the [frm.FileStore], and their values are the resulting [FileReference]s
+		return nil
+	case FormFieldTypePhone:
+		for _, v := range value {
+			if number, err := NormalizePhoneNumber(v, f.DefaultCountry); err == nil {
+				return number
+			}
+		}
+		return nil
+	case FormFieldTypeNPS, FormFieldTypeStarRating:
+		for _, v := range value {
+			if rating, err := f.parseRating(v); err == nil {
+				return rating
+			}
+		}
+		return nil
+	case FormFieldTypeRanking:
+		// untouched rankings are unanswered, rather than ranked in the order in which their options were shown
+		if len(value) == 0 {
+			return nil
+		}
+		return value
+	default:
+		return value
+	}
+}
+
 // MarshalJSON implements the json.Marshaler interface for FormFieldType
 func (f FormField) MarshalJSON() ([]byte, error) {
 	id := uuid.Nil
@@ -216,38 +598,15 @@ func (f FormField) MarshalJSON() ([]byte, error) {
 
 	// only confiugre logic when logic is _completely_ configured
 	var logic *FieldLogic
-	if f.Logic != nil && f.Logic.TargetFieldID != uuid.Nil && len(f.Logic.TriggerValues) > 0 && f.Logic.TriggerValues[0] != "" {
-		logic = f.Logic
+	if f.HasLogic() {
+		logic = f.Logic.normalized()
 	}
 
-	d := struct {
-		ID           uuid.UUID            `json:"id"`            // field's unique id
-		Order        int                  `json:"order"`         // order in which the field appears on forms
-		Label        string               `json:"label"`         // field's label (name)
-		Logic        *FieldLogic          `json:"logic"`         // field's logic configuration
-		Options      FieldOptions         `json:"options"`       // single/multi-select options
-		OptionLabels []string             `json:"option_labels"` // labels for [FormFieldTypeSingleChoice] options
-		OptionOrder  FormFieldOptionOrder `json:"option_order"`  // the order in which options appear
-		Placeholder  string               `json:"placeholder"`   // placeholder value
-		Required     bool                 `json:"required"`      // whether the field is required
-		Hidden       bool                 `json:"hidden"`        // whether the field is hidden
-		Type         FormFieldType        `json:"type"`          // field type
-		DataType     FormFieldDataType    `json:"data_type"`     // field's data type
-	}{
-
-		ID:           id,
-		Order:        f.Order,
-		Label:        f.Label,
-		Options:      f.Options,
-		OptionLabels: f.OptionLabels,
-		OptionOrder:  f.OptionOrder,
-		Placeholder:  f.Placeholder,
-		Required:     f.Required,
-		Hidden:       f.Hidden,
-		Type:         f.Type,
-		Logic:        logic,
-		DataType:     f.DataType,
-	}
+	// formField has FormField's fields but none of its methods, so that marshaling it does not recurse into MarshalJSON
+	type formField FormField
+	d := formField(f)
+	d.ID = id
+	d.Logic = logic
 
 	return json.Marshal(d)
 }
@@ -268,6 +627,44 @@ func (f *FormField) SortedOptions() (sorted []Option) {
 	}
 }
 
+// FormatNumber formats optional numbers as strings, without trailing zeros. nil numbers are formatted as empty strings.
+func FormatNumber(n *float64) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*n, 'f', -1, 64)
+}
+
+// parseNumber parses finite numbers from strings
+func parseNumber(value string) (n float64, err error) {
+	n, err = strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return
+	}
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, strconv.ErrSyntax
+	}
+	return
+}
+
+// normalizeEmail trims whitespace from email addresses and lowercases their domain
+//
+// ok is false when the address is not a bare email address, e.g. "name@example.com"
+func normalizeEmail(value string) (email string, ok bool) {
+	value = strings.TrimSpace(value)
+	addr, err := mail.ParseAddress(value)
+	if err != nil || addr.Address != value {
+		return value, false
+	}
+	at := strings.LastIndex(value, "@")
+	local, domain := value[:at], value[at+1:]
+	if !strings.Contains(domain, ".") || strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") {
+		return value, false
+	}
+
+	return fmt.Sprintf("%s@%s", local, strings.ToLower(domain)), true
+}
+
 // allValid checks if all field submission values are valid options
 func allValid(field FormField, subset []string) bool {
 	set := make(map[string]bool)
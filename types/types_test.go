@@ -0,0 +1,990 @@
+package types_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/acaloiaro/frm/types"
+	"github.com/google/uuid"
+)
+
+func TestValidateEmail(t *testing.T) {
+	field := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeEmail}
+	valid := []string{"name@example.com", "  Name.Surname+tag@Example.COM  "}
+	for _, v := range valid {
+		if err := field.Validate([]string{v}); err != nil {
+			t.Errorf("expected '%s' to be valid, got: %v", v, err)
+		}
+	}
+
+	invalid := []string{"name", "name@", "@example.com", "name@example", "Name <name@example.com>", "name@@example.com"}
+	for _, v := range invalid {
+		if err := field.Validate([]string{v}); err != types.ErrInvalidEmail {
+			t.Errorf("expected '%s' to be invalid, got: %v", v, err)
+		}
+	}
+
+	if err := field.Validate([]string{""}); err != nil {
+		t.Errorf("optional email fields should accept empty values, got: %v", err)
+	}
+
+	field.Required = true
+	if err := field.Validate([]string{""}); err != types.ErrRequiredNoValueProvided {
+		t.Errorf("required email fields should not accept empty values, got: %v", err)
+	}
+}
+
+func TestSubmissionValueEmail(t *testing.T) {
+	field := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeEmail}
+	got := field.SubmissionValue([]string{"  Name.Surname@Example.COM "})
+	expected := []string{"Name.Surname@example.com"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected: %v but got: %v", expected, got)
+	}
+}
+
+func TestValidateNumber(t *testing.T) {
+	min, max, step := 1.0, 10.0, 0.5
+	field := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeNumber, Min: &min, Max: &max, Step: &step}
+	for _, v := range []string{"1", "1.5", "10", " 7 ", ""} {
+		if err := field.Validate([]string{v}); err != nil {
+			t.Errorf("expected '%s' to be valid, got: %v", v, err)
+		}
+	}
+
+	tests := map[string]error{
+		"abc":  types.ErrNotANumber,
+		"NaN":  types.ErrNotANumber,
+		"0.5":  types.ErrNumberOutOfRange,
+		"10.5": types.ErrNumberOutOfRange,
+		"1.25": types.ErrNumberStep,
+	}
+	for v, expected := range tests {
+		if err := field.Validate([]string{v}); !errors.Is(err, expected) {
+			t.Errorf("expected '%s' to fail with '%v', got: %v", v, expected, err)
+		}
+	}
+
+	field.IntegerOnly = true
+	if err := field.Validate([]string{"1.5"}); err != types.ErrNotAnInteger {
+		t.Errorf("expected whole numbers only, got: %v", err)
+	}
+}
+
+func TestSubmissionValueNumber(t *testing.T) {
+	field := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeNumber}
+	if got := field.SubmissionValue([]string{"2.5"}); got != 2.5 {
+		t.Errorf("expected: 2.5 but got: %v", got)
+	}
+
+	if got := field.SubmissionValue([]string{""}); got != nil {
+		t.Errorf("expected empty numbers to be stored as nil, got: %v", got)
+	}
+
+	field.IntegerOnly = true
+	if got := field.SubmissionValue([]string{"42"}); got != int64(42) {
+		t.Errorf("expected: 42 but got: %v", got)
+	}
+}
+
+func TestValidateDate(t *testing.T) {
+	field := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeDate, Earliest: "2025-01-01", Latest: "2025-12-31"}
+	if err := field.Validate([]string{"2025-06-15"}); err != nil {
+		t.Errorf("expected date to be valid, got: %v", err)
+	}
+	if err := field.Validate([]string{"15/06/2025"}); err != types.ErrInvalidDate {
+		t.Errorf("expected invalid date, got: %v", err)
+	}
+	for _, v := range []string{"2024-12-31", "2026-01-01"} {
+		if err := field.Validate([]string{v}); !errors.Is(err, types.ErrDateOutOfRange) {
+			t.Errorf("expected '%s' to be out of range, got: %v", v, err)
+		}
+	}
+
+	field = types.FormField{ID: uuid.New(), Type: types.FormFieldTypeDate, Earliest: types.DateBoundNow}
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format(types.DateLayout)
+	if err := field.Validate([]string{yesterday}); !errors.Is(err, types.ErrDateOutOfRange) {
+		t.Errorf("expected dates in the past to be out of range, got: %v", err)
+	}
+	if err := field.Validate([]string{time.Now().UTC().Format(types.DateLayout)}); err != nil {
+		t.Errorf("expected today to be valid, got: %v", err)
+	}
+
+	// "now" is resolved in UTC, where it's already the next day
+	evening := time.Date(2026, time.January, 1, 23, 30, 0, 0, time.FixedZone("EST", -5*60*60))
+	if bound, ok := field.TemporalBound(field.Earliest, evening); !ok || bound != "2026-01-02" {
+		t.Errorf("expected 'now' to be resolved in UTC, got: %s", bound)
+	}
+}
+
+func TestSubmissionValueDateTime(t *testing.T) {
+	tests := map[types.FormFieldType][2]string{
+		types.FormFieldTypeDate:     {"2025-06-15", "2025-06-15"},
+		types.FormFieldTypeTime:     {"09:30", "09:30:00"},
+		types.FormFieldTypeDateTime: {"2025-06-15T09:30", "2025-06-15T09:30:00"},
+	}
+	for fieldType, test := range tests {
+		field := types.FormField{ID: uuid.New(), Type: fieldType}
+		if err := field.Validate([]string{test[0]}); err != nil {
+			t.Errorf("expected '%s' to be a valid %s, got: %v", test[0], fieldType, err)
+		}
+		if got := field.SubmissionValue([]string{test[0]}); got != test[1] {
+			t.Errorf("expected: %s but got: %v", test[1], got)
+		}
+	}
+}
+
+func TestValidateFile(t *testing.T) {
+	field := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeFile, MimeTypes: []string{"application/pdf", "image/*"}, MaxFileSize: 1024}
+	for _, contentType := range []string{"application/pdf", "image/png", "image/jpeg"} {
+		if err := field.ValidateFile(contentType, 512); err != nil {
+			t.Errorf("expected '%s' to be allowed, got: %v", contentType, err)
+		}
+	}
+	if err := field.ValidateFile("text/plain; charset=utf-8", 512); err != types.ErrFileTypeNotAllowed {
+		t.Errorf("expected text files not to be allowed, got: %v", err)
+	}
+	if err := field.ValidateFile("application/pdf", 2048); !errors.Is(err, types.ErrFileTooLarge) {
+		t.Errorf("expected file to be too large, got: %v", err)
+	}
+}
+
+func TestValidateRating(t *testing.T) {
+	stars := 3.0
+	tests := []struct {
+		field   types.FormField
+		valid   []string
+		invalid []string
+	}{
+		{
+			field:   types.FormField{ID: uuid.New(), Type: types.FormFieldTypeNPS},
+			valid:   []string{"0", "7", "10"},
+			invalid: []string{"-1", "11", "7.5", "great"},
+		},
+		{
+			field:   types.FormField{ID: uuid.New(), Type: types.FormFieldTypeStarRating, Max: &stars},
+			valid:   []string{"1", "3"},
+			invalid: []string{"0", "4"},
+		},
+	}
+	for _, test := range tests {
+		for _, v := range test.valid {
+			if err := test.field.Validate([]string{v}); err != nil {
+				t.Errorf("expected '%s' to be a valid %s, got: %v", v, test.field.Type, err)
+			}
+		}
+		for _, v := range test.invalid {
+			if err := test.field.Validate([]string{v}); err != types.ErrInvalidRating {
+				t.Errorf("expected '%s' to be an invalid %s, got: %v", v, test.field.Type, err)
+			}
+		}
+	}
+
+	field := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeNPS}
+	if got := field.SubmissionValue([]string{"9"}); got != 9 {
+		t.Errorf("expected ratings to be stored as integers, got: %#v", got)
+	}
+}
+
+func TestValidateCheckboxGroup(t *testing.T) {
+	field := types.FormField{
+		ID:         uuid.New(),
+		Type:       types.FormFieldTypeCheckboxGroup,
+		Options:    types.FieldOptions{{Value: "a"}, {Value: "b"}, {Value: "c"}},
+		MinChoices: 2,
+		MaxChoices: 2,
+	}
+	if err := field.Validate([]string{"a", "c"}); err != nil {
+		t.Errorf("expected choices to be valid, got: %v", err)
+	}
+	if err := field.Validate([]string{"a", "d"}); err != types.ErrUnknownOptionProvided {
+		t.Errorf("expected unknown option, got: %v", err)
+	}
+	if err := field.Validate([]string{"a"}); !errors.Is(err, types.ErrTooFewChoices) {
+		t.Errorf("expected too few choices, got: %v", err)
+	}
+	if err := field.Validate([]string{"a", "b", "c"}); !errors.Is(err, types.ErrTooManyChoices) {
+		t.Errorf("expected too many choices, got: %v", err)
+	}
+
+	field.Required = true
+	if err := field.Validate(nil); err != types.ErrRequiredNoValueProvided {
+		t.Errorf("required checkbox groups should require a choice, got: %v", err)
+	}
+}
+
+func TestValidateConsent(t *testing.T) {
+	field := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeConsent, Required: true}
+	if err := field.Validate([]string{"false", types.ConsentValue}); err != nil {
+		t.Errorf("expected consent to be valid, got: %v", err)
+	}
+	if err := field.Validate([]string{"false"}); err != types.ErrConsentRequired {
+		t.Errorf("required consent fields must be ticked, got: %v", err)
+	}
+
+	if got := field.SubmissionValue([]string{"false", types.ConsentValue}); got != true {
+		t.Errorf("expected consent to be stored as true, got: %#v", got)
+	}
+	if got := field.SubmissionValue([]string{"false"}); got != false {
+		t.Errorf("expected no consent to be stored as false, got: %#v", got)
+	}
+}
+
+func TestLabelSegments(t *testing.T) {
+	field := types.FormField{Label: "I accept the [terms](https://example.com/terms) and [privacy policy](https://example.com/privacy)."}
+	expected := []types.LabelSegment{
+		{Text: "I accept the "},
+		{Text: "terms", URL: "https://example.com/terms"},
+		{Text: " and "},
+		{Text: "privacy policy", URL: "https://example.com/privacy"},
+		{Text: "."},
+	}
+	if got := field.LabelSegments(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected: %v but got: %v", expected, got)
+	}
+
+	field.Label = "Run [this](javascript:alert(1))"
+	if got := field.LabelSegments(); len(got) != 1 || got[0].URL != "" {
+		t.Errorf("expected only http(s) links to be recognized, got: %v", got)
+	}
+}
+
+func TestValidateMatrix(t *testing.T) {
+	field := types.FormField{
+		ID:      uuid.New(),
+		Type:    types.FormFieldTypeMatrix,
+		Options: types.FieldOptions{{Value: "disagree"}, {Value: "agree"}},
+		Rows:    types.FieldOptions{{Value: "fast", Label: "It is fast", Order: 0}, {Value: "easy", Label: "It is easy", Order: 1}},
+	}
+	if err := field.ValidateParts(map[string][]string{"fast": {"agree"}}); err != nil {
+		t.Errorf("expected optional rows to be skippable, got: %v", err)
+	}
+	if err := field.ValidateParts(map[string][]string{"fast": {"maybe"}}); !errors.Is(err, types.ErrUnknownOptionProvided) {
+		t.Errorf("expected unknown option, got: %v", err)
+	}
+	if err := field.ValidateParts(map[string][]string{"cheap": {"agree"}}); err != types.ErrUnknownMatrixRow {
+		t.Errorf("expected unknown row, got: %v", err)
+	}
+
+	field.Required = true
+	if err := field.ValidateParts(map[string][]string{"fast": {"agree"}}); !errors.Is(err, types.ErrMatrixRowRequired) {
+		t.Errorf("expected every row to be required, got: %v", err)
+	}
+	if err := field.ValidateParts(nil); !errors.Is(err, types.ErrMatrixRowRequired) {
+		t.Errorf("expected unanswered matrix to fail, got: %v", err)
+	}
+
+	got := field.PartsSubmissionValue(map[string][]string{"fast": {"agree"}, "easy": {"disagree"}})
+	expected := map[string]string{"fast": "agree", "easy": "disagree"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected: %v but got: %v", expected, got)
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	field := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeAddress}
+	if err := field.ValidateParts(nil); err != nil {
+		t.Errorf("expected optional addresses to be skippable, got: %v", err)
+	}
+	if err := field.ValidateParts(map[string][]string{"planet": {"Earth"}}); err != types.ErrUnknownAddressPart {
+		t.Errorf("expected unknown part, got: %v", err)
+	}
+
+	field.Required = true
+	if err := field.ValidateParts(map[string][]string{"street": {"1 Main St"}}); !errors.Is(err, types.ErrAddressIncomplete) {
+		t.Errorf("expected every part to be required, got: %v", err)
+	}
+
+	field.Required = false
+	field.RequiredParts = []string{types.AddressPartStreet, types.AddressPartCity}
+	if err := field.ValidateParts(nil); err != nil {
+		t.Errorf("expected optional addresses with required parts to be skippable, got: %v", err)
+	}
+	if err := field.ValidateParts(map[string][]string{"street": {"1 Main St"}}); !errors.Is(err, types.ErrAddressIncomplete) {
+		t.Errorf("expected answered addresses to require their required parts, got: %v", err)
+	}
+	parts := map[string][]string{"street": {" 1 Main St "}, "city": {"Springfield"}, "postal_code": {""}}
+	if err := field.ValidateParts(parts); err != nil {
+		t.Errorf("expected address to be valid, got: %v", err)
+	}
+
+	got := field.PartsSubmissionValue(parts)
+	expected := types.Address{Street: "1 Main St", City: "Springfield"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected: %v but got: %v", expected, got)
+	}
+	if got := field.PartsSubmissionValue(nil); got != nil {
+		t.Errorf("expected unanswered addresses to have no value, got: %v", got)
+	}
+}
+
+func TestValidateSlider(t *testing.T) {
+	field := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeSlider}
+	for _, value := range []string{"0", "42", "100"} {
+		if err := field.Validate([]string{value}); err != nil {
+			t.Errorf("expected %s to be valid, got: %v", value, err)
+		}
+	}
+	if err := field.Validate([]string{"101"}); !errors.Is(err, types.ErrNumberOutOfRange) {
+		t.Errorf("expected values above the default maximum to be out of range, got: %v", err)
+	}
+	if err := field.Validate([]string{"4.5"}); !errors.Is(err, types.ErrNumberStep) {
+		t.Errorf("expected values between steps to be invalid, got: %v", err)
+	}
+
+	low, high, step := 1.0, 2.0, 0.25
+	field.Min, field.Max, field.Step = &low, &high, &step
+	if err := field.Validate([]string{"1.75"}); err != nil {
+		t.Errorf("expected 1.75 to be valid, got: %v", err)
+	}
+	if err := field.Validate([]string{"0"}); !errors.Is(err, types.ErrNumberOutOfRange) {
+		t.Errorf("expected values below the minimum to be out of range, got: %v", err)
+	}
+	if got := field.SubmissionValue([]string{"1.75"}); got != 1.75 {
+		t.Errorf("expected: 1.75 but got: %v", got)
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	field := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeTextSingle, MinLength: 3, MaxLength: 5}
+	if err := field.Validate([]string{"ab"}); !errors.Is(err, types.ErrTooShort) {
+		t.Errorf("expected answer to be too short, got: %v", err)
+	}
+	if err := field.Validate([]string{"abcdef"}); !errors.Is(err, types.ErrTooLong) {
+		t.Errorf("expected answer to be too long, got: %v", err)
+	}
+	if err := field.Validate([]string{"héllo"}); err != nil {
+		t.Errorf("expected length to be counted in characters, got: %v", err)
+	}
+	if err := field.Validate([]string{""}); err != nil {
+		t.Errorf("expected unanswered optional fields to skip rules, got: %v", err)
+	}
+
+	field = types.FormField{ID: uuid.New(), Type: types.FormFieldTypeTextSingle, Pattern: "[A-Z]{2}[0-9]{2}"}
+	if err := field.Validate([]string{"AB12"}); err != nil {
+		t.Errorf("expected answer to match pattern, got: %v", err)
+	}
+	if err := field.Validate([]string{"xAB12"}); err != types.ErrPatternMismatch {
+		t.Errorf("expected patterns to match entire answers, got: %v", err)
+	}
+
+	field.ValidationMessage = "Please enter your membership number, e.g. AB12"
+	err := field.Validate([]string{"12"})
+	if !errors.Is(err, types.ErrPatternMismatch) || err.Error() != field.ValidationMessage {
+		t.Errorf("expected custom message wrapping pattern mismatch, got: %v", err)
+	}
+
+	field.Pattern = "[A-Z"
+	if _, err := field.PatternRegexp(); !errors.Is(err, types.ErrInvalidPattern) {
+		t.Errorf("expected invalid pattern, got: %v", err)
+	}
+	if err := field.Validate([]string{"anything"}); err != nil {
+		t.Errorf("expected invalid patterns to be ignored, got: %v", err)
+	}
+
+	email := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeEmail, Pattern: `.+@example\.com`}
+	if err := email.Validate([]string{"name@elsewhere.com"}); err != types.ErrPatternMismatch {
+		t.Errorf("expected email pattern mismatch, got: %v", err)
+	}
+}
+
+func TestValidateCrossField(t *testing.T) {
+	email := types.FormField{ID: uuid.New(), Label: "Email", Type: types.FormFieldTypeEmail}
+	confirm := types.FormField{ID: uuid.New(), Label: "Confirm email", Type: types.FormFieldTypeEmail}
+	confirm.CrossFieldRules = []types.CrossFieldRule{{Kind: types.CrossFieldRuleKindEqual, FieldIDs: []uuid.UUID{email.ID}}}
+	start := types.FormField{ID: uuid.New(), Label: "Start", Type: types.FormFieldTypeDate}
+	end := types.FormField{ID: uuid.New(), Label: "End", Type: types.FormFieldTypeDate}
+	end.CrossFieldRules = []types.CrossFieldRule{{Kind: types.CrossFieldRuleKindAfter, FieldIDs: []uuid.UUID{start.ID}, Message: "The end must be after the start"}}
+	phone := types.FormField{ID: uuid.New(), Label: "Phone", Type: types.FormFieldTypePhone}
+	phone.CrossFieldRules = []types.CrossFieldRule{{Kind: types.CrossFieldRuleKindAtLeastOne, FieldIDs: []uuid.UUID{email.ID}}}
+	fields := types.FormFields{email.ID.String(): email, confirm.ID.String(): confirm, start.ID.String(): start, end.ID.String(): end, phone.ID.String(): phone}
+
+	values := map[string][]string{email.ID.String(): {"name@example.com"}, confirm.ID.String(): {"name@EXAMPLE.com"}}
+	if err := confirm.ValidateCrossField(fields, values); err != nil {
+		t.Errorf("expected emails to match, got: %v", err)
+	}
+	values[confirm.ID.String()] = []string{"other@example.com"}
+	if err := confirm.ValidateCrossField(fields, values); !errors.Is(err, types.ErrValuesDoNotMatch) {
+		t.Errorf("expected emails not to match, got: %v", err)
+	}
+	values[confirm.ID.String()] = []string{"Name@example.com"}
+	if err := confirm.ValidateCrossField(fields, values); !errors.Is(err, types.ErrValuesDoNotMatch) {
+		t.Errorf("expected emails with differently cased local parts not to match, got: %v", err)
+	}
+
+	values = map[string][]string{start.ID.String(): {"2025-01-02"}, end.ID.String(): {"2025-01-01"}}
+	err := end.ValidateCrossField(fields, values)
+	if !errors.Is(err, types.ErrValuesOutOfOrder) || err.Error() != "The end must be after the start" {
+		t.Errorf("expected end before start to fail with custom message, got: %v", err)
+	}
+	values[end.ID.String()] = []string{"2025-01-03"}
+	if err := end.ValidateCrossField(fields, values); err != nil {
+		t.Errorf("expected end after start, got: %v", err)
+	}
+	if err := end.ValidateCrossField(fields, map[string][]string{end.ID.String(): {"2025-01-03"}}); err != nil {
+		t.Errorf("expected unanswered fields not to be ordered, got: %v", err)
+	}
+
+	if err := phone.ValidateCrossField(fields, map[string][]string{phone.ID.String(): {""}}); !errors.Is(err, types.ErrAtLeastOneRequired) {
+		t.Errorf("expected at least one answer to be required, got: %v", err)
+	}
+	if err := phone.ValidateCrossField(fields, map[string][]string{email.ID.String(): {"name@example.com"}}); err != nil {
+		t.Errorf("expected one answer to satisfy the rule, got: %v", err)
+	}
+}
+
+func TestEvaluateLogic(t *testing.T) {
+	plan := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeTextSingle}
+	seats := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeNumber, Logic: &types.FieldLogic{
+		TargetFieldID:     plan.ID,
+		TriggerComparator: types.FieldLogicComparatorEqual,
+		TriggerValues:     []string{"pro"},
+		TriggerActions:    types.FieldLogicTriggerActions{types.FieldLogicTriggerRequire},
+	}}
+	discount := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeTextSingle, Logic: &types.FieldLogic{
+		TargetFieldID:     seats.ID,
+		TriggerComparator: types.FieldLogicComparatorContains,
+		TriggerValues:     []string{"0"},
+		TriggerActions:    types.FieldLogicTriggerActions{types.FieldLogicTriggerShow},
+	}}
+	notes := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeTextSingle, Hidden: true}
+	fields := types.FormFields{plan.ID.String(): plan, seats.ID.String(): seats, discount.ID.String(): discount, notes.ID.String(): notes}
+
+	states := fields.EvaluateLogic(map[string][]string{plan.ID.String(): {"PRO"}, seats.ID.String(): {"10"}})
+	if state := states[seats.ID.String()]; state.Hidden || state.Excluded || !state.Required {
+		t.Errorf("expected seats to be shown and required, got: %+v", state)
+	}
+	if state := states[discount.ID.String()]; state.Hidden || state.Required {
+		t.Errorf("expected discount to be shown and optional, got: %+v", state)
+	}
+	if state := states[notes.ID.String()]; !state.Hidden || state.Excluded {
+		t.Errorf("expected hidden fields without logic to be hidden, but not excluded, got: %+v", state)
+	}
+
+	states = fields.EvaluateLogic(map[string][]string{plan.ID.String(): {"basic"}, seats.ID.String(): {"10"}})
+	if state := states[seats.ID.String()]; !state.Excluded || state.Required {
+		t.Errorf("expected seats to be excluded, got: %+v", state)
+	}
+	if state := states[discount.ID.String()]; !state.Excluded {
+		t.Errorf("expected fields targeting excluded fields to be excluded, got: %+v", state)
+	}
+
+	cyclic := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeTextSingle}
+	cyclic.Logic = &types.FieldLogic{TargetFieldID: cyclic.ID, TriggerValues: []string{"x"}, TriggerActions: types.FieldLogicTriggerActions{types.FieldLogicTriggerShow}}
+	states = types.FormFields{cyclic.ID.String(): cyclic}.EvaluateLogic(map[string][]string{cyclic.ID.String(): {"x"}})
+	if state := states[cyclic.ID.String()]; state.Excluded {
+		t.Errorf("expected logic targeting its own field to be evaluated, got: %+v", state)
+	}
+}
+
+func TestEvaluateCompoundLogic(t *testing.T) {
+	country := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeTextSingle}
+	age := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeNumber}
+	consent := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeConsent}
+	guardian := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeTextSingle, Logic: &types.FieldLogic{
+		TriggerActions: types.FieldLogicTriggerActions{types.FieldLogicTriggerShow},
+		Operator:       types.FieldLogicOperatorOr,
+		Groups: []types.FieldLogicGroup{
+			{
+				Operator: types.FieldLogicOperatorAnd,
+				Conditions: []types.FieldLogicCondition{
+					{TargetFieldID: country.ID, Comparator: types.FieldLogicComparatorEqual, Values: []string{"US"}},
+					{TargetFieldID: age.ID, Comparator: types.FieldLogicComparatorEqual, Values: []string{"17"}},
+				},
+			},
+			{
+				Conditions: []types.FieldLogicCondition{
+					{TargetFieldID: consent.ID, Comparator: types.FieldLogicComparatorEqual, Values: []string{"false"}},
+					{TargetFieldID: age.ID}, // incomplete conditions are ignored
+				},
+			},
+		},
+	}}
+	fields := types.FormFields{country.ID.String(): country, age.ID.String(): age, consent.ID.String(): consent, guardian.ID.String(): guardian}
+
+	tests := []struct {
+		values map[string][]string
+		shown  bool
+	}{
+		{values: map[string][]string{country.ID.String(): {"us"}, age.ID.String(): {"17"}, consent.ID.String(): {"false", "true"}}, shown: true},
+		{values: map[string][]string{country.ID.String(): {"us"}, age.ID.String(): {"30"}, consent.ID.String(): {"false", "true"}}, shown: false},
+		{values: map[string][]string{country.ID.String(): {"CA"}, age.ID.String(): {"30"}, consent.ID.String(): {"false"}}, shown: true},
+	}
+	for _, test := range tests {
+		state := fields.EvaluateLogic(test.values)[guardian.ID.String()]
+		if state.Hidden == test.shown || state.Excluded == test.shown {
+			t.Errorf("expected guardian to be shown: %t given values %v, got: %+v", test.shown, test.values, state)
+		}
+	}
+}
+
+func TestEvaluateLogicActions(t *testing.T) {
+	plan := types.FormField{ID: uuid.New(), Order: 0, Type: types.FormFieldTypeTextSingle}
+	when := func(value string, actions ...types.FieldLogicTriggerAction) *types.FieldLogic {
+		return &types.FieldLogic{TargetFieldID: plan.ID, TriggerValues: []string{value}, TriggerActions: actions, Value: "team"}
+	}
+	seats := types.FormField{ID: uuid.New(), Order: 1, Type: types.FormFieldTypeTextSingle, Required: true, Logic: when("free", types.FieldLogicTriggerHide)}
+	workspace := types.FormField{ID: uuid.New(), Order: 2, Type: types.FormFieldTypeTextSingle, Required: true, Logic: when("pro", types.FieldLogicTriggerDisable, types.FieldLogicTriggerSetValue)}
+	end := types.FormField{ID: uuid.New(), Order: 3, Type: types.FormFieldTypeParagraph, Logic: when("free", types.FieldLogicTriggerEndForm)}
+	feedback := types.FormField{ID: uuid.New(), Order: 4, Type: types.FormFieldTypeTextSingle}
+	fields := types.FormFields{plan.ID.String(): plan, seats.ID.String(): seats, workspace.ID.String(): workspace, end.ID.String(): end, feedback.ID.String(): feedback}
+
+	states := fields.EvaluateLogic(map[string][]string{plan.ID.String(): {"free"}})
+	if state := states[seats.ID.String()]; !state.Hidden || !state.Excluded || state.Required {
+		t.Errorf("expected seats to be hidden, got: %+v", state)
+	}
+	if state := states[end.ID.String()]; !state.Ends || state.Excluded {
+		t.Errorf("expected the form to end, got: %+v", state)
+	}
+	if state := states[feedback.ID.String()]; !state.Excluded {
+		t.Errorf("expected fields after the end of the form to be excluded, got: %+v", state)
+	}
+
+	states = fields.EvaluateLogic(map[string][]string{plan.ID.String(): {"pro"}})
+	if state := states[workspace.ID.String()]; !state.Disabled || state.Required || !reflect.DeepEqual(state.Value, []string{"team"}) {
+		t.Errorf("expected workspace to be read-only and set to 'team', got: %+v", state)
+	}
+	if state := states[seats.ID.String()]; state.Hidden || !state.Required {
+		t.Errorf("expected seats to keep its static state, got: %+v", state)
+	}
+	if state := states[feedback.ID.String()]; state.Excluded {
+		t.Errorf("expected feedback to be included, got: %+v", state)
+	}
+}
+
+func TestMatchesNumericAndEmptinessComparators(t *testing.T) {
+	score := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeNPS}
+	size := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeSingleChoice, DataType: types.FormFieldDataTypeNumeric, Options: types.FieldOptions{
+		{ID: uuid.New(), Value: "small", Label: "10"},
+		{ID: uuid.New(), Value: "large", Label: "20"},
+	}}
+	name := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeTextSingle}
+
+	tests := []struct {
+		condition types.FieldLogicCondition
+		target    types.FormField
+		value     []string
+		expected  bool
+	}{
+		{condition: types.FieldLogicCondition{Comparator: types.FieldLogicComparatorLessThan, Values: []string{"7"}}, target: score, value: []string{"6"}, expected: true},
+		{condition: types.FieldLogicCondition{Comparator: types.FieldLogicComparatorLessThan, Values: []string{"7"}}, target: score, value: []string{"7"}, expected: false},
+		{condition: types.FieldLogicCondition{Comparator: types.FieldLogicComparatorLessThan, Values: []string{"7"}}, target: score, value: nil, expected: false},
+		{condition: types.FieldLogicCondition{Comparator: types.FieldLogicComparatorGreaterThan, Values: []string{"8"}}, target: score, value: []string{"9"}, expected: true},
+		{condition: types.FieldLogicCondition{Comparator: types.FieldLogicComparatorBetween, Values: []string{"15", "25"}}, target: size, value: []string{"large"}, expected: true},
+		{condition: types.FieldLogicCondition{Comparator: types.FieldLogicComparatorBetween, Values: []string{"15", "25"}}, target: size, value: []string{"small"}, expected: false},
+		{condition: types.FieldLogicCondition{Comparator: types.FieldLogicComparatorGreaterThan, Values: []string{"1"}}, target: name, value: []string{"5"}, expected: false},
+		{condition: types.FieldLogicCondition{Comparator: types.FieldLogicComparatorIsEmpty}, target: name, value: []string{" "}, expected: true},
+		{condition: types.FieldLogicCondition{Comparator: types.FieldLogicComparatorIsNotEmpty}, target: name, value: []string{"Ada"}, expected: true},
+	}
+	for _, test := range tests {
+		if got := test.condition.Matches(test.target, test.value); got != test.expected {
+			t.Errorf("expected %s %v to match %v: %t, got: %t", test.condition.Comparator, test.condition.Values, test.value, test.expected, got)
+		}
+	}
+
+	if slices.Contains(name.LogicComparators(), types.FieldLogicComparatorGreaterThan) {
+		t.Error("expected text fields not to be compared numerically")
+	}
+	if !slices.Contains(size.LogicComparators(), types.FieldLogicComparatorBetween) {
+		t.Error("expected choice fields with numeric data to be compared numerically")
+	}
+	if !(types.FieldLogicCondition{TargetFieldID: name.ID, Comparator: types.FieldLogicComparatorIsEmpty}).IsComplete() {
+		t.Error("expected emptiness conditions to be complete without values")
+	}
+}
+
+func TestIsContent(t *testing.T) {
+	for _, fieldType := range []types.FormFieldType{types.FormFieldTypeHeading, types.FormFieldTypeParagraph, types.FormFieldTypeDivider, types.FormFieldTypeImage} {
+		if !(types.FormField{Type: fieldType}).IsContent() {
+			t.Errorf("expected %s to be a content block", fieldType)
+		}
+	}
+	if (types.FormField{Type: types.FormFieldTypeTextSingle}).IsContent() {
+		t.Error("expected text fields not to be content blocks")
+	}
+}
+
+func TestValidateRanking(t *testing.T) {
+	field := types.FormField{
+		ID:      uuid.New(),
+		Type:    types.FormFieldTypeRanking,
+		Options: types.FieldOptions{{Value: "a"}, {Value: "b"}, {Value: "c"}},
+	}
+	if err := field.Validate([]string{"c", "a", "b"}); err != nil {
+		t.Errorf("expected ranking to be valid, got: %v", err)
+	}
+	for _, ranking := range [][]string{{"a", "b"}, {"a", "a", "b"}, {"a", "b", "d"}, {"a", "b", "c", "c"}} {
+		if err := field.Validate(ranking); err != types.ErrInvalidRanking {
+			t.Errorf("expected %v to be an invalid ranking, got: %v", ranking, err)
+		}
+	}
+	got := field.SubmissionValue([]string{"c", "a", "b"})
+	if expected := []string{"c", "a", "b"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected rankings to be stored in order: %v but got: %v", expected, got)
+	}
+
+	// untouched rankings submit no values, and are unanswered
+	if err := field.Validate(nil); err != nil {
+		t.Errorf("expected an untouched optional ranking to be valid, got: %v", err)
+	}
+	if got := field.SubmissionValue(nil); got != nil {
+		t.Errorf("expected an untouched ranking to be stored without a value, got: %v", got)
+	}
+	field.Required = true
+	if err := field.Validate(nil); err != types.ErrRequiredNoValueProvided {
+		t.Errorf("expected an untouched required ranking to be unanswered, got: %v", err)
+	}
+}
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	tests := []struct {
+		number   string
+		country  string
+		expected string
+	}{
+		{number: "(415) 555-2671", country: "US", expected: "+14155552671"},
+		{number: "1 415 555 2671", country: "US", expected: "+14155552671"},
+		{number: "020 7946 0018", country: "GB", expected: "+442079460018"},
+		{number: "+44 20 7946 0018", country: "US", expected: "+442079460018"},
+		{number: "0041 44 668 18 00", country: "", expected: "+41446681800"},
+		{number: "06 12 34 56 78", country: "FR", expected: "+33612345678"},
+		{number: "01 2345", country: "AT", expected: "+4312345"},
+		{number: "+43 1 2345", country: "US", expected: "+4312345"},
+	}
+	for _, test := range tests {
+		got, err := types.NormalizePhoneNumber(test.number, test.country)
+		if err != nil || got != test.expected {
+			t.Errorf("expected '%s' to normalize to %s, got: %s (%v)", test.number, test.expected, got, err)
+		}
+	}
+
+	for _, number := range []string{"555-2671", "+1 415 555 26711", "+43 123", "phone", "+0 123 456 789", "4155552671x"} {
+		if _, err := types.NormalizePhoneNumber(number, "US"); err != types.ErrInvalidPhoneNumber {
+			t.Errorf("expected '%s' to be invalid, got: %v", number, err)
+		}
+	}
+}
+
+func TestValidatePhone(t *testing.T) {
+	field := types.FormField{ID: uuid.New(), Type: types.FormFieldTypePhone, DefaultCountry: "US"}
+	if err := field.Validate([]string{"415-555-2671"}); err != nil {
+		t.Errorf("expected phone number to be valid, got: %v", err)
+	}
+	if err := field.Validate([]string{"12345"}); err != types.ErrInvalidPhoneNumber {
+		t.Errorf("expected phone number to be invalid, got: %v", err)
+	}
+	if got := field.SubmissionValue([]string{"415-555-2671"}); got != "+14155552671" {
+		t.Errorf("expected phone numbers to be stored as E.164, got: %v", got)
+	}
+}
+
+func TestValidateSignature(t *testing.T) {
+	field := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeSignature, Required: true}
+	png := "\x89PNG\r\n\x1a\n" + "image data"
+	signature := "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte(png))
+	if err := field.Validate([]string{signature}); err != nil {
+		t.Errorf("expected signature to be valid, got: %v", err)
+	}
+	if got, err := types.DecodeSignature(signature); err != nil || string(got) != png {
+		t.Errorf("expected signature to decode to a PNG image, got: %q (%v)", got, err)
+	}
+
+	invalid := []string{
+		"data:image/jpeg;base64," + base64.StdEncoding.EncodeToString([]byte(png)),
+		"data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte("not a png")),
+		"data:image/png;base64,!!!",
+	}
+	for _, v := range invalid {
+		if err := field.Validate([]string{v}); !errors.Is(err, types.ErrInvalidSignature) {
+			t.Errorf("expected '%s' to be an invalid signature, got: %v", v, err)
+		}
+	}
+	if err := field.Validate([]string{""}); err != types.ErrRequiredNoValueProvided {
+		t.Errorf("required signature fields must be signed, got: %v", err)
+	}
+}
+
+func TestPrefillValue(t *testing.T) {
+	query := url.Values{"utm_source": {"newsletter"}}
+	data := types.ShortCodeData{"account": "42"}
+
+	field := types.FormField{ID: uuid.New(), Prefill: types.PrefillSourceQuery, PrefillKey: "utm_source"}
+	if value, ok := field.PrefillValue(query, data); !ok || value != "newsletter" {
+		t.Errorf("expected value from query string, got: %s", value)
+	}
+
+	field = types.FormField{ID: uuid.New(), Prefill: types.PrefillSourceShortCode, PrefillKey: "account"}
+	if value, ok := field.PrefillValue(query, data); !ok || value != "42" {
+		t.Errorf("expected value from short code data, got: %s", value)
+	}
+
+	field.PrefillKey = "utm_source"
+	if _, ok := field.PrefillValue(query, data); ok {
+		t.Error("expected short code prefilled fields to ignore the query string")
+	}
+
+	field = types.FormField{ID: uuid.New(), PrefillKey: "utm_source"}
+	if field.IsPrefilled() {
+		t.Error("expected fields without a prefill source not to be prefilled")
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	price := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeNumber}
+	quantity := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeNumber}
+	stars := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeStarRating}
+	subtotal := types.FormField{
+		ID:         uuid.New(),
+		Type:       types.FormFieldTypeCalculated,
+		Expression: fmt.Sprintf("%s * %s", price.CalculationReference(), quantity.CalculationReference()),
+	}
+	total := types.FormField{
+		ID:         uuid.New(),
+		Type:       types.FormFieldTypeCalculated,
+		Expression: fmt.Sprintf("(%s + 5) / 2 - -%s", subtotal.CalculationReference(), stars.CalculationReference()),
+	}
+	fields := types.FormFields{}
+	for _, field := range []types.FormField{price, quantity, stars, subtotal, total} {
+		fields[field.ID.String()] = field
+	}
+	values := map[string][]string{
+		price.ID.String():    {"2.5"},
+		quantity.ID.String(): {"4"},
+		stars.ID.String():    {"3"},
+	}
+
+	if got, err := total.Calculate(fields, values); err != nil || got != 10.5 {
+		t.Errorf("expected: 10.5 but got: %v (%v)", got, err)
+	}
+
+	delete(values, quantity.ID.String())
+	if got, err := subtotal.Calculate(fields, values); err != nil || got != 0 {
+		t.Errorf("expected unanswered fields to count as 0, got: %v (%v)", got, err)
+	}
+
+	for _, expression := range []string{"", "1 +", "(1", "1 $ 2", "{" + uuid.NewString() + "}"} {
+		invalid := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeCalculated, Expression: expression}
+		if err := invalid.ValidateExpression(fields); !errors.Is(err, types.ErrInvalidExpression) {
+			t.Errorf("expected '%s' to be an invalid expression, got: %v", expression, err)
+		}
+	}
+
+	divide := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeCalculated, Expression: "1 / " + quantity.CalculationReference()}
+	if _, err := divide.Calculate(fields, values); err != types.ErrDivisionByZero {
+		t.Errorf("expected division by zero, got: %v", err)
+	}
+
+	cycle := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeCalculated}
+	cycle.Expression = cycle.CalculationReference() + " + 1"
+	fields[cycle.ID.String()] = cycle
+	if _, err := cycle.Calculate(fields, values); err != types.ErrCalculationCycle {
+		t.Errorf("expected calculation cycle, got: %v", err)
+	}
+}
+
+func TestLint(t *testing.T) {
+	deleted := uuid.New()
+	a := types.FormField{ID: uuid.New(), Order: 0, Type: types.FormFieldTypeTextSingle, Label: "Name"}
+	b := types.FormField{ID: uuid.New(), Order: 1, Type: types.FormFieldTypeTextSingle, Label: "name "}
+	a.Logic = &types.FieldLogic{TargetFieldID: b.ID, TriggerValues: []string{"x"}}
+	b.Logic = &types.FieldLogic{TargetFieldID: a.ID, TriggerValues: []string{"y"}}
+	dangling := types.FormField{ID: uuid.New(), Order: 2, Type: types.FormFieldTypeTextSingle, Label: "Company", Logic: &types.FieldLogic{
+		TargetFieldID: deleted, TriggerValues: []string{"z"},
+	}}
+	empty := types.FormField{ID: uuid.New(), Order: 3, Type: types.FormFieldTypeSingleSelect, Label: "Plan"}
+	fields := types.FormFields{a.ID.String(): a, b.ID.String(): b, dangling.ID.String(): dangling, empty.ID.String(): empty}
+
+	problems := fields.Lint()
+	expected := []struct {
+		fieldID  uuid.UUID
+		severity types.ProblemSeverity
+	}{
+		{fieldID: a.ID, severity: types.ProblemSeverityError},        // cycle
+		{fieldID: b.ID, severity: types.ProblemSeverityError},        // cycle
+		{fieldID: b.ID, severity: types.ProblemSeverityWarning},      // duplicate label
+		{fieldID: dangling.ID, severity: types.ProblemSeverityError}, // dangling target
+		{fieldID: empty.ID, severity: types.ProblemSeverityError},    // no options
+	}
+	if len(problems) != len(expected) {
+		t.Fatalf("expected %d problems, got: %+v", len(expected), problems)
+	}
+	for i, problem := range problems {
+		if problem.FieldID != expected[i].fieldID || problem.Severity != expected[i].severity {
+			t.Errorf("expected problem %d to be a %s with field %s, got: %+v", i, expected[i].severity, expected[i].fieldID, problem)
+		}
+	}
+	if !problems.HasErrors() {
+		t.Error("expected problems to have errors")
+	}
+	if problems := (types.FormFields{a.ID.String(): {ID: a.ID, Label: "Name"}}).Lint(); len(problems) > 0 {
+		t.Errorf("expected no problems, got: %+v", problems)
+	}
+}
+
+func TestCalculateChoiceScales(t *testing.T) {
+	scale := func(label string, dataType types.FormFieldDataType) types.FormField {
+		return types.FormField{ID: uuid.New(), Type: types.FormFieldTypeSingleChoiceSpaced, Label: label, DataType: dataType, Options: types.FieldOptions{
+			{ID: uuid.New(), Value: "low", Label: "1"},
+			{ID: uuid.New(), Value: "high", Label: "5"},
+		}}
+	}
+	rating := scale("Satisfaction", types.FormFieldDataTypeRating)
+	textual := scale("Comfort", types.FormFieldDataTypeText)
+	doubled := types.FormField{ID: uuid.New(), Type: types.FormFieldTypeCalculated, Label: "Doubled", Expression: rating.CalculationReference() + " * 2"}
+	fields := types.FormFields{rating.ID.String(): rating, textual.ID.String(): textual, doubled.ID.String(): doubled}
+
+	if !rating.IsNumeric() || textual.IsNumeric() {
+		t.Error("expected only choice fields with numeric or rating data types to be numeric")
+	}
+	if got, err := doubled.Calculate(fields, map[string][]string{rating.ID.String(): {"high"}}); err != nil || got != 10 {
+		t.Errorf("expected: 10 but got: %v (%v)", got, err)
+	}
+	if problems := fields.Lint(); len(problems) > 0 {
+		t.Errorf("expected calculations referencing rating scales to be valid, got: %+v", problems)
+	}
+}
+
+func TestLintOptionsParentsAndCalculations(t *testing.T) {
+	options := func(values ...string) (options types.FieldOptions) {
+		for _, v := range values {
+			options = append(options, types.Option{ID: uuid.New(), Value: v, Label: v})
+		}
+		return
+	}
+	region := types.FormField{ID: uuid.New(), Order: 0, Type: types.FormFieldTypeSingleSelect, Label: "Region", Options: options("north")}
+	city := types.FormField{ID: uuid.New(), Order: 1, Type: types.FormFieldTypeSingleSelect, Label: "City", Options: options("oslo"), OptionsParentID: &region.ID}
+	region.OptionsParentID = &city.ID
+	price := types.FormField{ID: uuid.New(), Order: 2, Type: types.FormFieldTypeNumber, Label: "Price"}
+	name := types.FormField{ID: uuid.New(), Order: 3, Type: types.FormFieldTypeTextSingle, Label: "Name"}
+	malformed := types.FormField{ID: uuid.New(), Order: 4, Type: types.FormFieldTypeCalculated, Label: "Total", Expression: price.CalculationReference() + " *"}
+	dangling := types.FormField{ID: uuid.New(), Order: 5, Type: types.FormFieldTypeCalculated, Label: "Tax", Expression: fmt.Sprintf("%s * {%s}", price.CalculationReference(), uuid.New())}
+	textual := types.FormField{ID: uuid.New(), Order: 6, Type: types.FormFieldTypeCalculated, Label: "Score", Expression: name.CalculationReference() + " + 1"}
+	valid := types.FormField{ID: uuid.New(), Order: 7, Type: types.FormFieldTypeCalculated, Label: "Discount", Expression: price.CalculationReference() + " / 10"}
+	fields := types.FormFields{}
+	for _, field := range []types.FormField{region, city, price, name, malformed, dangling, textual, valid} {
+		fields[field.ID.String()] = field
+	}
+
+	problems := fields.Lint()
+	expected := []uuid.UUID{region.ID, city.ID, malformed.ID, dangling.ID, textual.ID}
+	if len(problems) != len(expected) {
+		t.Fatalf("expected %d problems, got: %+v", len(expected), problems)
+	}
+	for i, problem := range problems {
+		if problem.FieldID != expected[i] || problem.Severity != types.ProblemSeverityError {
+			t.Errorf("expected problem %d to be an error with field %s, got: %+v", i, expected[i], problem)
+		}
+	}
+	if expected := "The options of 'Region' depend on themselves: Region → City → Region"; problems[0].Message != expected {
+		t.Errorf("expected message %q, got: %q", expected, problems[0].Message)
+	}
+}
+
+func TestDeleteProblems(t *testing.T) {
+	country := types.FormField{ID: uuid.New(), Order: 0, Type: types.FormFieldTypeSingleSelect, Label: "Country", Options: types.FieldOptions{{ID: uuid.New(), Value: "us", Label: "United States"}}}
+	region := types.FormField{ID: uuid.New(), Order: 1, Type: types.FormFieldTypeSingleSelect, Label: "Region", OptionsParentID: &country.ID, Options: types.FieldOptions{{ID: uuid.New(), Value: "texas", Label: "Texas"}}}
+	price := types.FormField{ID: uuid.New(), Order: 2, Type: types.FormFieldTypeNumber, Label: "Price"}
+	shipping := types.FormField{ID: uuid.New(), Order: 3, Type: types.FormFieldTypeTextSingle, Label: "Shipping", Logic: &types.FieldLogic{
+		TargetFieldID:     country.ID,
+		TriggerComparator: types.FieldLogicComparatorEqual,
+		TriggerValues:     []string{"us"},
+		TriggerActions:    types.FieldLogicTriggerActions{types.FieldLogicTriggerShow},
+	}}
+	total := types.FormField{ID: uuid.New(), Order: 4, Type: types.FormFieldTypeCalculated, Label: "Total", Expression: price.CalculationReference() + " * 2"}
+	fields := types.FormFields{}
+	for _, field := range []types.FormField{country, region, price, shipping, total} {
+		fields[field.ID.String()] = field
+	}
+
+	tests := []struct {
+		deleted  types.FormField
+		expected []uuid.UUID
+	}{
+		{deleted: country, expected: []uuid.UUID{region.ID, shipping.ID}},
+		{deleted: price, expected: []uuid.UUID{total.ID}},
+		{deleted: shipping, expected: nil},
+	}
+	for _, test := range tests {
+		var dependents []uuid.UUID
+		for _, problem := range fields.DeleteProblems(test.deleted.ID.String()) {
+			dependents = append(dependents, problem.FieldID)
+		}
+		if !reflect.DeepEqual(dependents, test.expected) {
+			t.Errorf("deleting '%s': expected problems with %v, got: %v", test.deleted.Label, test.expected, dependents)
+		}
+	}
+	if len(fields) != 5 {
+		t.Error("expected DeleteProblems not to modify the fields")
+	}
+}
+
+func TestCascadingOptions(t *testing.T) {
+	country := types.FormField{ID: uuid.New(), Order: 0, Type: types.FormFieldTypeSingleSelect, Options: types.FieldOptions{
+		{ID: uuid.New(), Value: "us", Label: "United States"},
+		{ID: uuid.New(), Value: "ca", Label: "Canada"},
+	}}
+	region := types.FormField{ID: uuid.New(), Order: 1, Type: types.FormFieldTypeSingleSelect, OptionsParentID: &country.ID, Options: types.FieldOptions{
+		{ID: uuid.New(), Value: "texas", Label: "Texas", ParentValues: []string{"us"}},
+		{ID: uuid.New(), Value: "ontario", Label: "Ontario", ParentValues: []string{"ca"}},
+		{ID: uuid.New(), Value: "other", Label: "Other"},
+	}}
+	fields := types.FormFields{country.ID.String(): country, region.ID.String(): region}
+
+	tests := []struct {
+		country []string
+		region  string
+		want    error
+	}{
+		{country: []string{"us"}, region: "texas", want: nil},
+		{country: []string{"us"}, region: "ontario", want: types.ErrOptionNotOffered},
+		{country: []string{"ca"}, region: "ontario", want: nil},
+		{country: []string{"ca"}, region: "other", want: nil},
+		{country: nil, region: "texas", want: types.ErrOptionNotOffered},
+		{country: []string{"us"}, region: "atlantis", want: types.ErrUnknownOptionProvided},
+	}
+	for _, test := range tests {
+		states := fields.EvaluateLogic(map[string][]string{country.ID.String(): test.country, region.ID.String(): {test.region}})
+		err := region.ValidateWithParent([]string{test.region}, states[region.ID.String()].Parent)
+		if !errors.Is(err, test.want) {
+			t.Errorf("country %v, region %q: expected %v, got: %v", test.country, test.region, test.want, err)
+		}
+	}
+
+	// fields validated without their options parent's answer reject conditioned options
+	if err := region.Validate([]string{"ontario"}); !errors.Is(err, types.ErrOptionNotOffered) {
+		t.Errorf("expected conditioned option to be rejected without the parent's answer, got: %v", err)
+	}
+	if err := region.Validate([]string{"other"}); err != nil {
+		t.Errorf("expected unconditioned option to be valid without the parent's answer, got: %v", err)
+	}
+
+	if stated := region.WithState(types.FieldState{Parent: []string{"ca"}}); !stated.Options[0].Disabled {
+		t.Error("expected WithState to disable options that are not offered")
+	}
+	if region.Options[0].Disabled {
+		t.Error("expected WithState not to modify the field's options")
+	}
+
+	// fields whose options don't depend on other answers are serialized without options parents
+	serialized, err := json.Marshal(country)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(serialized), "options_parent_id") {
+		t.Errorf("expected no options parent to be serialized, got: %s", serialized)
+	}
+
+	// options depend on fields that exist
+	delete(fields, country.ID.String())
+	if problems := fields.Lint(); !problems.HasErrors() {
+		t.Errorf("expected options depending on a deleted field to be an error, got: %+v", problems)
+	}
+}
@@ -333,6 +333,7 @@ type LabeledCheckboxArgs struct {
 	Name        string // field name attribute
 	Title       string // field title attribute
 	ID          string // field id attribute
+	Value       string // value submitted when checked, "on" by default
 	Checked     bool   // value is checked
 	Required    bool   // field is required
 	Tooltip     string // text tooltip
@@ -368,7 +369,7 @@ func LabeledCheckbox(args LabeledCheckboxArgs) templ.Component {
 		var templ_7745c5c3_Var16 string
 		templ_7745c5c3_Var16, templ_7745c5c3_Err = templ.JoinStringErrs(args.ID)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 114, Col: 15}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 115, Col: 15}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var16))
 		if templ_7745c5c3_Err != nil {
@@ -381,7 +382,7 @@ func LabeledCheckbox(args LabeledCheckboxArgs) templ.Component {
 		var templ_7745c5c3_Var17 string
 		templ_7745c5c3_Var17, templ_7745c5c3_Err = templ.JoinStringErrs(args.Name)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 115, Col: 19}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 116, Col: 19}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var17))
 		if templ_7745c5c3_Err != nil {
@@ -391,78 +392,97 @@ func LabeledCheckbox(args LabeledCheckboxArgs) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
+		if args.Value != "" {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 22, " value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var18 string
+			templ_7745c5c3_Var18, templ_7745c5c3_Err = templ.JoinStringErrs(args.Value)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 119, Col: 22}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var18))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 23, "\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
 		if args.Checked {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 22, " checked=\"checked\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 24, " checked=\"checked\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
 		if args.Required {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 23, " required")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 25, " required")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 24, " class=\"checkbox\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 26, " class=\"checkbox\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		if args.Hyperscript != "" {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 25, " _=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 27, " _=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			var templ_7745c5c3_Var18 string
-			templ_7745c5c3_Var18, templ_7745c5c3_Err = templ.JoinStringErrs(args.Hyperscript)
+			var templ_7745c5c3_Var19 string
+			templ_7745c5c3_Var19, templ_7745c5c3_Err = templ.JoinStringErrs(args.Hyperscript)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 125, Col: 24}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 129, Col: 24}
 			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var18))
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var19))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 26, "\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 28, "\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 27, "> <span class=\"pl-3\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 29, "> <span class=\"pl-3\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var19 string
-		templ_7745c5c3_Var19, templ_7745c5c3_Err = templ.JoinStringErrs(args.Label)
+		var templ_7745c5c3_Var20 string
+		templ_7745c5c3_Var20, templ_7745c5c3_Err = templ.JoinStringErrs(args.Label)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 128, Col: 33}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 132, Col: 33}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var19))
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var20))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 28, "</span> ")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 30, "</span> ")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		if args.Tooltip != "" {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 29, "<div class=\"tooltip tooltip-left px-3\" data-tip=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 31, "<div class=\"tooltip tooltip-left px-3\" data-tip=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			var templ_7745c5c3_Var20 string
-			templ_7745c5c3_Var20, templ_7745c5c3_Err = templ.JoinStringErrs(args.Tooltip)
+			var templ_7745c5c3_Var21 string
+			templ_7745c5c3_Var21, templ_7745c5c3_Err = templ.JoinStringErrs(args.Tooltip)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 130, Col: 65}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 134, Col: 65}
 			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var20))
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var21))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 30, "\"><svg data-src=\"https://unpkg.com/heroicons/20/solid/question-mark-circle.svg\" class=\"inline-block h-5 w-5 text-success\"></svg></div>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 32, "\"><svg data-src=\"https://unpkg.com/heroicons/20/solid/question-mark-circle.svg\" class=\"inline-block h-5 w-5 text-success\"></svg></div>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 31, "</label>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 33, "</label>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -502,43 +522,43 @@ func LabeledSelector(args LabeledSelectorArgs) templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var21 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var21 == nil {
-			templ_7745c5c3_Var21 = templ.NopComponent
+		templ_7745c5c3_Var22 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var22 == nil {
+			templ_7745c5c3_Var22 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		var templ_7745c5c3_Var22 = []any{fmt.Sprintf("flex flex-col h-fit w-full input input-bordered flex px-0 %s", args.LabelClass)}
-		templ_7745c5c3_Err = templ.RenderCSSItems(ctx, templ_7745c5c3_Buffer, templ_7745c5c3_Var22...)
+		var templ_7745c5c3_Var23 = []any{fmt.Sprintf("flex flex-col h-fit w-full input input-bordered flex px-0 %s", args.LabelClass)}
+		templ_7745c5c3_Err = templ.RenderCSSItems(ctx, templ_7745c5c3_Buffer, templ_7745c5c3_Var23...)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 32, "<label class=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 34, "<label class=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var23 string
-		templ_7745c5c3_Var23, templ_7745c5c3_Err = templ.JoinStringErrs(templ.CSSClasses(templ_7745c5c3_Var22).String())
+		var templ_7745c5c3_Var24 string
+		templ_7745c5c3_Var24, templ_7745c5c3_Err = templ.JoinStringErrs(templ.CSSClasses(templ_7745c5c3_Var23).String())
 		if templ_7745c5c3_Err != nil {
 			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 1, Col: 0}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var23))
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var24))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 33, "\"><span class=\"inline-flex h-full group-[.label-group]:min-w-48 bg-primary-100 items-center rounded-tl-lg rounded-tr-lg px-3\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 35, "\"><span class=\"inline-flex h-full group-[.label-group]:min-w-48 bg-primary-100 items-center rounded-tl-lg rounded-tr-lg px-3\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var24 string
-		templ_7745c5c3_Var24, templ_7745c5c3_Err = templ.JoinStringErrs(args.Label)
+		var templ_7745c5c3_Var25 string
+		templ_7745c5c3_Var25, templ_7745c5c3_Err = templ.JoinStringErrs(args.Label)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 160, Col: 138}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 164, Col: 138}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var24))
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var25))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 34, "</span>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 36, "</span>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -557,7 +577,7 @@ func LabeledSelector(args LabeledSelectorArgs) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 35, "</label>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 37, "</label>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -595,179 +615,179 @@ func LabeledTextInput(args LabeledTextInputArgs) templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var25 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var25 == nil {
-			templ_7745c5c3_Var25 = templ.NopComponent
+		templ_7745c5c3_Var26 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var26 == nil {
+			templ_7745c5c3_Var26 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		var templ_7745c5c3_Var26 = []any{fmt.Sprintf("flex flex-col h-fit w-full %s", args.LabelClass)}
-		templ_7745c5c3_Err = templ.RenderCSSItems(ctx, templ_7745c5c3_Buffer, templ_7745c5c3_Var26...)
+		var templ_7745c5c3_Var27 = []any{fmt.Sprintf("flex flex-col h-fit w-full %s", args.LabelClass)}
+		templ_7745c5c3_Err = templ.RenderCSSItems(ctx, templ_7745c5c3_Buffer, templ_7745c5c3_Var27...)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 36, "<label class=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 38, "<label class=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var27 string
-		templ_7745c5c3_Var27, templ_7745c5c3_Err = templ.JoinStringErrs(templ.CSSClasses(templ_7745c5c3_Var26).String())
+		var templ_7745c5c3_Var28 string
+		templ_7745c5c3_Var28, templ_7745c5c3_Err = templ.JoinStringErrs(templ.CSSClasses(templ_7745c5c3_Var27).String())
 		if templ_7745c5c3_Err != nil {
 			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 1, Col: 0}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var27))
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var28))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 37, "\"><span class=\"inline-flex h-full w-full group-[.label-group]:min-w-48 bg-primary-100 rounded-tr-lg rounded-tl-lg items-center px-3\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 39, "\"><span class=\"inline-flex h-full w-full group-[.label-group]:min-w-48 bg-primary-100 rounded-tr-lg rounded-tl-lg items-center px-3\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var28 string
-		templ_7745c5c3_Var28, templ_7745c5c3_Err = templ.JoinStringErrs(args.Label)
+		var templ_7745c5c3_Var29 string
+		templ_7745c5c3_Var29, templ_7745c5c3_Err = templ.JoinStringErrs(args.Label)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 193, Col: 15}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 197, Col: 15}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var28))
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var29))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 38, " ")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 40, " ")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		if !args.Required {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 39, "<span class=\"badge badge-info bg-slate-100 mx-3\">Optional</span>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 41, "<span class=\"badge badge-info bg-slate-100 mx-3\">Optional</span>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 40, "</span><div class=\"flex items-center\"><input class=\"bg-slate-100 w-full border-0 rounded-br-lg rounded-bl-lg\" type=\"text\" name=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 42, "</span><div class=\"flex items-center\"><input class=\"bg-slate-100 w-full border-0 rounded-br-lg rounded-bl-lg\" type=\"text\" name=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var29 string
-		templ_7745c5c3_Var29, templ_7745c5c3_Err = templ.JoinStringErrs(args.Name)
+		var templ_7745c5c3_Var30 string
+		templ_7745c5c3_Var30, templ_7745c5c3_Err = templ.JoinStringErrs(args.Name)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 202, Col: 20}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 206, Col: 20}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var29))
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var30))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 41, "\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 43, "\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		if args.ID != "" {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 42, " id=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 44, " id=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			var templ_7745c5c3_Var30 string
-			templ_7745c5c3_Var30, templ_7745c5c3_Err = templ.JoinStringErrs(args.ID)
+			var templ_7745c5c3_Var31 string
+			templ_7745c5c3_Var31, templ_7745c5c3_Err = templ.JoinStringErrs(args.ID)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 204, Col: 17}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 208, Col: 17}
 			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var30))
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var31))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 43, "\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 45, "\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
 		if args.Placeholder != "" {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 44, " placeholder=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 46, " placeholder=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			var templ_7745c5c3_Var31 string
-			templ_7745c5c3_Var31, templ_7745c5c3_Err = templ.JoinStringErrs(args.Placeholder)
+			var templ_7745c5c3_Var32 string
+			templ_7745c5c3_Var32, templ_7745c5c3_Err = templ.JoinStringErrs(args.Placeholder)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 207, Col: 35}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 211, Col: 35}
 			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var31))
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var32))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 45, "\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 47, "\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
 		if args.Value != "" {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 46, " value=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 48, " value=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			var templ_7745c5c3_Var32 string
-			templ_7745c5c3_Var32, templ_7745c5c3_Err = templ.JoinStringErrs(args.Value)
+			var templ_7745c5c3_Var33 string
+			templ_7745c5c3_Var33, templ_7745c5c3_Err = templ.JoinStringErrs(args.Value)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 210, Col: 23}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 214, Col: 23}
 			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var32))
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var33))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 47, "\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 49, "\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
 		if args.Required {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 48, " required")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 50, " required")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 49, " class=\"border-0\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 51, " class=\"border-0\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		if args.Hyperscript != "" {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 50, " _=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 52, " _=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			var templ_7745c5c3_Var33 string
-			templ_7745c5c3_Var33, templ_7745c5c3_Err = templ.JoinStringErrs(args.Hyperscript)
+			var templ_7745c5c3_Var34 string
+			templ_7745c5c3_Var34, templ_7745c5c3_Err = templ.JoinStringErrs(args.Hyperscript)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 217, Col: 25}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 221, Col: 25}
 			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var33))
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var34))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 51, "\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 53, "\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 52, "></div>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 54, "></div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		if args.Tooltip != "" {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 53, "<div class=\"tooltip tooltip-left px-3 py-3\" data-tip=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 55, "<div class=\"tooltip tooltip-left px-3 py-3\" data-tip=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			var templ_7745c5c3_Var34 string
-			templ_7745c5c3_Var34, templ_7745c5c3_Err = templ.JoinStringErrs(args.Tooltip)
+			var templ_7745c5c3_Var35 string
+			templ_7745c5c3_Var35, templ_7745c5c3_Err = templ.JoinStringErrs(args.Tooltip)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 222, Col: 70}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 226, Col: 70}
 			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var34))
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var35))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 54, "\"><svg data-src=\"https://unpkg.com/heroicons/20/solid/question-mark-circle.svg\" class=\"inline-block h-5 w-5 text-success\"></svg></div>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 56, "\"><svg data-src=\"https://unpkg.com/heroicons/20/solid/question-mark-circle.svg\" class=\"inline-block h-5 w-5 text-success\"></svg></div>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 55, "</label>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 57, "</label>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -795,33 +815,33 @@ func FieldSet(args FieldsetArgs) templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var35 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var35 == nil {
-			templ_7745c5c3_Var35 = templ.NopComponent
+		templ_7745c5c3_Var36 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var36 == nil {
+			templ_7745c5c3_Var36 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 56, "<fieldset class=\"fieldset flex flex-col gap-2 p-4 bg-base-100 border border-base-300 rounded-box w-full\"><legend class=\"fieldset-legend\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 58, "<fieldset class=\"fieldset flex flex-col gap-2 p-4 bg-base-100 border border-base-300 rounded-box w-full\"><legend class=\"fieldset-legend\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var36 string
-		templ_7745c5c3_Var36, templ_7745c5c3_Err = templ.JoinStringErrs(args.Label)
+		var templ_7745c5c3_Var37 string
+		templ_7745c5c3_Var37, templ_7745c5c3_Err = templ.JoinStringErrs(args.Label)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 238, Col: 46}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 242, Col: 46}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var36))
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var37))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 57, "</legend>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 59, "</legend>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templ_7745c5c3_Var35.Render(ctx, templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = templ_7745c5c3_Var36.Render(ctx, templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 58, "</fieldset>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 60, "</fieldset>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -846,29 +866,29 @@ func Head(pageTitle string) templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var37 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var37 == nil {
-			templ_7745c5c3_Var37 = templ.NopComponent
+		templ_7745c5c3_Var38 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var38 == nil {
+			templ_7745c5c3_Var38 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 59, "<head><title>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 61, "<head><title>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var38 string
-		templ_7745c5c3_Var38, templ_7745c5c3_Err = templ.JoinStringErrs(pageTitle)
+		var templ_7745c5c3_Var39 string
+		templ_7745c5c3_Var39, templ_7745c5c3_Err = templ.JoinStringErrs(pageTitle)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 246, Col: 20}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 250, Col: 20}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var38))
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var39))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 60, "</title><meta charset=\"UTF-8\"><meta name=\"viewport\" content=\"width=device-width, initial-scale=1.0\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 62, "</title><meta charset=\"UTF-8\"><meta name=\"viewport\" content=\"width=device-width, initial-scale=1.0\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Var39 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_Var40 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 			templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 			templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 			if !templ_7745c5c3_IsBuffer {
@@ -880,212 +900,212 @@ func Head(pageTitle string) templ.Component {
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 61, "<link href=\"")
-			if templ_7745c5c3_Err != nil {
-				return templ_7745c5c3_Err
-			}
-			var templ_7745c5c3_Var40 string
-			templ_7745c5c3_Var40, templ_7745c5c3_Err = templ.JoinStringErrs(frm.CollectorPath(ctx, "/static/css/styles.css"))
-			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 250, Col: 64}
-			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var40))
-			if templ_7745c5c3_Err != nil {
-				return templ_7745c5c3_Err
-			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 62, "\" rel=\"stylesheet\"><link rel=\"stylesheet\" href=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 63, "<link href=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var41 string
-			templ_7745c5c3_Var41, templ_7745c5c3_Err = templ.JoinStringErrs(frm.CollectorPath(ctx, "/static/css/choices.min.css"))
+			templ_7745c5c3_Var41, templ_7745c5c3_Err = templ.JoinStringErrs(frm.CollectorPath(ctx, "/static/css/styles.css"))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 251, Col: 86}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 254, Col: 64}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var41))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 63, "\" nonce=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 64, "\" rel=\"stylesheet\"><link rel=\"stylesheet\" href=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var42 string
-			templ_7745c5c3_Var42, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
+			templ_7745c5c3_Var42, templ_7745c5c3_Err = templ.JoinStringErrs(frm.CollectorPath(ctx, "/static/css/choices.min.css"))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 251, Col: 116}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 255, Col: 86}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var42))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 64, "\"><script type=\"text/javascript\" src=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 65, "\" nonce=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var43 string
-			templ_7745c5c3_Var43, templ_7745c5c3_Err = templ.JoinStringErrs(frm.CollectorPath(ctx, "/static/js/htmx.js"))
+			templ_7745c5c3_Var43, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 252, Col: 84}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 255, Col: 116}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var43))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 65, "\" nonce=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 66, "\"><script type=\"text/javascript\" src=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var44 string
-			templ_7745c5c3_Var44, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
+			templ_7745c5c3_Var44, templ_7745c5c3_Err = templ.JoinStringErrs(frm.CollectorPath(ctx, "/static/js/htmx.js"))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 252, Col: 114}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 256, Col: 84}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var44))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 66, "\"></script> <script type=\"text/javascript\" src=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 67, "\" nonce=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var45 string
-			templ_7745c5c3_Var45, templ_7745c5c3_Err = templ.JoinStringErrs(frm.CollectorPath(ctx, "/static/js/htmx-response-targets.js"))
+			templ_7745c5c3_Var45, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 253, Col: 101}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 256, Col: 114}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var45))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 67, "\" nonce=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 68, "\"></script> <script type=\"text/javascript\" src=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var46 string
-			templ_7745c5c3_Var46, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
+			templ_7745c5c3_Var46, templ_7745c5c3_Err = templ.JoinStringErrs(frm.CollectorPath(ctx, "/static/js/htmx-response-targets.js"))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 253, Col: 131}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 257, Col: 101}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var46))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 68, "\"></script> <script type=\"text/javascript\" src=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 69, "\" nonce=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var47 string
-			templ_7745c5c3_Var47, templ_7745c5c3_Err = templ.JoinStringErrs(frm.CollectorPath(ctx, "/static/js/hyperscript.js"))
+			templ_7745c5c3_Var47, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 254, Col: 91}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 257, Col: 131}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var47))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 69, "\" nonce=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 70, "\"></script> <script type=\"text/javascript\" src=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var48 string
-			templ_7745c5c3_Var48, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
+			templ_7745c5c3_Var48, templ_7745c5c3_Err = templ.JoinStringErrs(frm.CollectorPath(ctx, "/static/js/hyperscript.js"))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 254, Col: 121}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 258, Col: 91}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var48))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 70, "\"></script> <script ytpe=\"text/javascript\" src=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 71, "\" nonce=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var49 string
-			templ_7745c5c3_Var49, templ_7745c5c3_Err = templ.JoinStringErrs(frm.CollectorPath(ctx, "/static/js/choices.min.js"))
+			templ_7745c5c3_Var49, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 255, Col: 91}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 258, Col: 121}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var49))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 71, "\" nonce=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 72, "\"></script> <script ytpe=\"text/javascript\" src=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var50 string
-			templ_7745c5c3_Var50, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
+			templ_7745c5c3_Var50, templ_7745c5c3_Err = templ.JoinStringErrs(frm.CollectorPath(ctx, "/static/js/choices.min.js"))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 255, Col: 121}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 259, Col: 91}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var50))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 72, "\"></script> <script type=\"text/javascript\" src=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 73, "\" nonce=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var51 string
-			templ_7745c5c3_Var51, templ_7745c5c3_Err = templ.JoinStringErrs(frm.CollectorPath(ctx, "/static/js/svg-loader.min.js"))
+			templ_7745c5c3_Var51, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 256, Col: 94}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 259, Col: 121}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var51))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 73, "\" nonce=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 74, "\"></script> <script type=\"text/javascript\" src=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var52 string
-			templ_7745c5c3_Var52, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
+			templ_7745c5c3_Var52, templ_7745c5c3_Err = templ.JoinStringErrs(frm.CollectorPath(ctx, "/static/js/svg-loader.min.js"))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 256, Col: 124}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 260, Col: 94}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var52))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 74, "\" async></script> <script type=\"text/javascript\" src=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 75, "\" nonce=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var53 string
-			templ_7745c5c3_Var53, templ_7745c5c3_Err = templ.JoinStringErrs(frm.CollectorPath(ctx, "/static/js/Sortable.min.js"))
+			templ_7745c5c3_Var53, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 257, Col: 92}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 260, Col: 124}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var53))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 75, "\" nonce=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 76, "\" async></script> <script type=\"text/javascript\" src=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var54 string
-			templ_7745c5c3_Var54, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
+			templ_7745c5c3_Var54, templ_7745c5c3_Err = templ.JoinStringErrs(frm.CollectorPath(ctx, "/static/js/Sortable.min.js"))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 257, Col: 122}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 261, Col: 92}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var54))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 76, "\"></script> <script type=\"text/javascript\">\n\t\t\t\thtmx.onLoad(function(content) {\n\t\t\t\t    var sortables = content.querySelectorAll(\".sortable\");\n\t\t\t\t    for (var i = 0; i < sortables.length; i++) {\n\t\t\t\t      var sortable = sortables[i];\n\t\t\t\t      var sortableInstance = new Sortable(sortable, {\n\t\t\t\t          animation: 150,\n\t\t\t\t\t\t  draggable: \".sortme\",\n\t\t\t\t          onMove: function (evt) {\n\t\t\t\t            return evt.related.className.indexOf('htmx-indicator') === -1;\n\t\t\t\t          },\n\t\t\t\t          onEnd: function (evt) {\n\t\t\t\t            this.option(\"disabled\", true);\n\t\t\t\t          }\n\t\t\t\t      });\n\t\t\t\t      // Re-enable sorting on the `htmx:afterSwap` event\n\t\t\t\t      sortable.addEventListener(\"htmx:afterSwap\", function() {\n\t\t\t\t        sortableInstance.option(\"disabled\", false);\n\t\t\t\t      });\n\t\t\t\t    }\n\t\t\t\t})\n\n\t\t\t\t// formValueChanged handles changes to user input in form fields\n\t\t\t\tfunction formValueChanged(fieldID, newValue) {\n\t\t\t\t\tvar formMetadata = JSON.parse(document.getElementById('form-metadata').getAttribute(\"data-data\"));\n\t\t\t\t\tif (formMetadata == null) {\n\t\t\t\t\t\treturn\n\t\t\t\t\t}\n\n\t\t\t\t\t// collect the fields that have logic monitoring the changed field \n\t\t\t\t\tvar watchingFields = Object.values(formMetadata.form.fields).filter(function(field) {\n\t\t\t\t\t\treturn field.logic != null && fieldID === field.logic.target_field_id\n\t\t\t\t\t});\n\n\t\t\t\t\t// no fields watch the one that changed\n\t\t\t\t\tif (watchingFields.length == 0) {\n\t\t\t\t\t\treturn\n\t\t\t\t\t}\n\t\t\t\t\tvar fieldElement = document.getElementById(fieldID)\n\t\t\t\t\tfor (i in watchingFields) {\n\t\t\t\t\t\tlet watchingField = watchingFields[i]\n\t\t\t\t\t\tlet match = false\n\t\t\t\t\t\tvar watcherFieldElement = document.getElementById(watchingField.id) // the actual element watching the field\n\t\t\t\t\t\t// radio form elements such as \"single choice\" elements cannot get gotten by ID because they are\n\t\t\t\t\t\t// radio button in a form group, all sharing a \"name\" attribute, rather than having one unique id\n\t\t\t\t\t\t// like other input elements. Thus, when we cannot get a watching field by ID, we must be able to get it by\n\t\t\t\t\t\t// name.\n\t\t\t\t\t\tif (watcherFieldElement == null) {\n\t\t\t\t\t\t\tradioFormElements = document.getElementsByName(watchingField.id)\n\t\t\t\t\t\t\tif (radioFormElements.length > 0) {\n\t\t\t\t\t\t\t\twatcherFieldElement = radioFormElements[0]\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t}\n\t\t\t\t\t\tvar watcherFieldContainerID = `field-container-${watchingField.id}` // the DOM element that contains the watching field\n\t\t\t\t\t\tlogic = watchingField.logic\n\n\t\t\t\t\t\t// check whether the new value is coming from a Choices.js field, in which case the new value\n\t\t\t\t\t\t// is the array of chosen values, joined by commas, otherwise newValue is used as it was passed in\n\t\t\t\t\t\tif (fieldElement != null && fieldElement._choices != null) {\n\t\t\t\t\t\t\t// Choics.getValue() returns scalar for single selects and array for multi. Use Array.of\n\t\t\t\t\t\t\t// to treat everything it returns as an array\n\t\t\t\t\t\t\tnewValue = Array.of(fieldElement._choices.getValue(true)).join(',')\n\t\t\t\t\t\t}\n\n\t\t\t\t\t\t// find if _any_ trigger values match the new value\n\t\t\t\t\t\tswitch (logic.field_comparator) {\n\t\t\t\t\t\t\tcase 'equal':\n\t\t\t\t\t\t\t\tmatch = watchingField.logic.trigger_values.every(val => newValue.localeCompare(val, 'en', {sensitivity: \"base\"}) == 0)\n\t\t\t\t\t\t\t\tbreak;\n\t\t\t\t\t\t\tcase 'contains':\n\t\t\t\t\t\t\t\tmatch = watchingField.logic.trigger_values.some(val => newValue.toLowerCase().includes(val.toLowerCase()))\n\t\t\t\t\t\t\t\tbreak;\n\t\t\t\t\t\t\tcase 'not':\n\t\t\t\t\t\t\t\tmatch = watchingField.logic.trigger_values.some(val => newValue.toLowerCase() !== val.toLowerCase())\n\t\t\t\t\t\t\t\tbreak;\n\t\t\t\t\t\t}\n\n\t\t\t\t\t\t// Most actions are likely to be performed upon the containing element, such as show/hide/require.\n\t\t\t\t\t\t// This may of course change or be expanded, but for now, only the watcher field container is relevant\n\t\t\t\t\t\t// to applying actions.\n\t\t\t\t\t\tlet el = document.getElementById(watcherFieldContainerID)\n\t\t\t\t\t\tlet actions = watchingField.logic.actions\n\n\t\t\t\t\t\t// we have a match, execute the action\n\t\t\t\t\t\tfor (i in actions) {\n\t\t\t\t\t\t\tswitch (actions[i]) {\n\t\t\t\t\t\t\t\tcase \"field_logic_trigger_show\":\n\t\t\t\t\t\t\t\t\tif (match) {\n\t\t\t\t\t\t\t\t\t\tel.classList.remove(\"hidden\")\n\t\t\t\t\t\t\t\t\t} else {\n\t\t\t\t\t\t\t\t\t\tel.classList.add(\"hidden\")\n\t\t\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\t\t\tbreak;\n\t\t\t\t\t\t\t\tcase \"field_logic_trigger_require\":\n\t\t\t\t\t\t\t\t\tif (match) {\n\t\t\t\t\t\t\t\t\t\tel.classList.remove(\"hidden\")\n\t\t\t\t\t\t\t\t\t\twatcherFieldElement.setAttribute(\"required\", \"\")\n\t\t\t\t\t\t\t\t\t} else {\n\t\t\t\t\t\t\t\t\t\tel.classList.add(\"hidden\")\n\t\t\t\t\t\t\t\t\t\twatcherFieldElement.removeAttribute(\"required\")\n\t\t\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\t\t\tbreak\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t}\n\t\t\t</script>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 77, "\" nonce=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var55 string
+			templ_7745c5c3_Var55, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 261, Col: 122}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var55))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 78, "\"></script> <script type=\"text/javascript\">\n\t\t\t\thtmx.onLoad(function(content) {\n\t\t\t\t    var sortables = content.querySelectorAll(\".sortable\");\n\t\t\t\t    for (var i = 0; i < sortables.length; i++) {\n\t\t\t\t      var sortable = sortables[i];\n\t\t\t\t      var sortableInstance = new Sortable(sortable, {\n\t\t\t\t          animation: 150,\n\t\t\t\t\t\t  draggable: \".sortme\",\n\t\t\t\t          onMove: function (evt) {\n\t\t\t\t            return evt.related.className.indexOf('htmx-indicator') === -1;\n\t\t\t\t          },\n\t\t\t\t          onEnd: function (evt) {\n\t\t\t\t            this.option(\"disabled\", true);\n\t\t\t\t          }\n\t\t\t\t      });\n\t\t\t\t      // Re-enable sorting on the `htmx:afterSwap` event\n\t\t\t\t      sortable.addEventListener(\"htmx:afterSwap\", function() {\n\t\t\t\t        sortableInstance.option(\"disabled\", false);\n\t\t\t\t      });\n\t\t\t\t    }\n\n\t\t\t\t    var signaturePads = content.querySelectorAll(\".signature-pad\");\n\t\t\t\t    for (var i = 0; i < signaturePads.length; i++) {\n\t\t\t\t      initSignaturePad(signaturePads[i]);\n\t\t\t\t    }\n\n\t\t\t\t    // calculated fields are recalculated whenever any of their form's values change\n\t\t\t\t    var calculations = content.querySelectorAll(\".calculation\");\n\t\t\t\t    for (var i = 0; i < calculations.length; i++) {\n\t\t\t\t      var form = calculations[i].closest(\"form\");\n\t\t\t\t      if (form && !form.dataset.calculating) {\n\t\t\t\t        form.dataset.calculating = \"true\";\n\t\t\t\t        form.addEventListener(\"input\", function (evt) { recalculate(evt.currentTarget) });\n\t\t\t\t        form.addEventListener(\"change\", function (evt) { recalculate(evt.currentTarget) });\n\t\t\t\t      }\n\t\t\t\t      if (form) {\n\t\t\t\t        recalculate(form);\n\t\t\t\t      }\n\t\t\t\t    }\n\n\t\t\t\t    // ranking fields are re-ordered by respondents, and report their new order as the field's value\n\t\t\t\t    var rankings = content.querySelectorAll(\".ranking\");\n\t\t\t\t    for (var i = 0; i < rankings.length; i++) {\n\t\t\t\t      new Sortable(rankings[i], {\n\t\t\t\t          animation: 150,\n\t\t\t\t          draggable: \".rankme\",\n\t\t\t\t          onEnd: function (evt) {\n\t\t\t\t            var ranking = evt.from;\n\t\t\t\t            var inputs = Array.from(ranking.querySelectorAll(\"input[type=hidden]\"));\n\t\t\t\t            // options are submitted once they've been ranked\n\t\t\t\t            inputs.forEach(input => input.name = input.dataset.name);\n\t\t\t\t            var ranked = inputs.map(input => input.value);\n\t\t\t\t            ranking.dispatchEvent(new CustomEvent(\"field_change\", {\n\t\t\t\t              bubbles: true,\n\t\t\t\t              detail: { field_id: ranking.dataset.fieldId, value: ranked.join(',') }\n\t\t\t\t            }));\n\t\t\t\t          }\n\t\t\t\t      });\n\t\t\t\t    }\n\t\t\t\t})\n\n\t\t\t\t// checkboxGroupChanged validates the number of checked boxes in a checkbox group, and returns the checked values\n\t\t\t\t// joined by commas\n\t\t\t\tfunction checkboxGroupChanged(fieldID, min, max) {\n\t\t\t\t\tvar boxes = Array.from(document.getElementsByName(fieldID))\n\t\t\t\t\tvar checked = boxes.filter(box => box.checked).map(box => box.value)\n\t\t\t\t\tvar message = \"\"\n\t\t\t\t\tif (checked.length > 0 && min > 0 && checked.length < min) {\n\t\t\t\t\t\tmessage = `Please choose at least ${min}`\n\t\t\t\t\t} else if (max > 0 && checked.length > max) {\n\t\t\t\t\t\tmessage = `Please choose at most ${max}`\n\t\t\t\t\t}\n\t\t\t\t\tboxes.forEach(box => box.setCustomValidity(\"\"))\n\t\t\t\t\tif (boxes.length > 0) {\n\t\t\t\t\t\tboxes[0].setCustomValidity(message)\n\t\t\t\t\t}\n\t\t\t\t\treturn checked.join(',')\n\t\t\t\t}\n\n\t\t\t\t// recalculate updates the values of a form's calculated fields\n\t\t\t\tfunction recalculate(form) {\n\t\t\t\t\tvar data = new FormData(form)\n\t\t\t\t\tvar metadata = document.getElementById('form-metadata')\n\t\t\t\t\tvar fields = metadata != null ? JSON.parse(metadata.getAttribute(\"data-data\")).form.fields : {}\n\t\t\t\t\tvar calculations = Array.from(form.querySelectorAll(\".calculation\"))\n\t\t\t\t\tvar calculating = {}\n\t\t\t\t\tvar resolve = function (fieldID) {\n\t\t\t\t\t\tvar calculation = calculations.find(c => c.id === fieldID)\n\t\t\t\t\t\tif (calculation) {\n\t\t\t\t\t\t\tif (calculating[fieldID]) {\n\t\t\t\t\t\t\t\tthrow new Error(\"calculated fields cannot depend on themselves\")\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\tcalculating[fieldID] = true\n\t\t\t\t\t\t\tvar result = evaluateExpression(calculation.dataset.expression, resolve)\n\t\t\t\t\t\t\tdelete calculating[fieldID]\n\t\t\t\t\t\t\treturn result\n\t\t\t\t\t\t}\n\t\t\t\t\t\t// choice fields' values are the numbers in the labels of their chosen options\n\t\t\t\t\t\tif (fields[fieldID] != null) {\n\t\t\t\t\t\t\treturn logicNumber(fields[fieldID], data.getAll(fieldID)) ?? 0\n\t\t\t\t\t\t}\n\t\t\t\t\t\tvar n = parseFloat(data.get(fieldID))\n\t\t\t\t\t\treturn isNaN(n) ? 0 : n\n\t\t\t\t\t}\n\t\t\t\t\tcalculations.forEach(function (calculation) {\n\t\t\t\t\t\ttry {\n\t\t\t\t\t\t\tvar result = evaluateExpression(calculation.dataset.expression, resolve)\n\t\t\t\t\t\t\tcalculation.value = isFinite(result) ? String(Number(result.toFixed(6))) : \"\"\n\t\t\t\t\t\t} catch (e) {\n\t\t\t\t\t\t\tcalculation.value = \"\"\n\t\t\t\t\t\t}\n\t\t\t\t\t})\n\t\t\t\t}\n\n\t\t\t\t// evaluateExpression evaluates calculated fields' arithmetic expressions, mirroring the server's evaluation.\n\t\t\t\t// Field references, e.g. {<field id>}, are resolved to numbers by resolve.\n\t\t\t\tfunction evaluateExpression(input, resolve) {\n\t\t\t\t\tvar pos = 0\n\t\t\t\t\tvar peek = function () {\n\t\t\t\t\t\twhile (pos < input.length && /\\s/.test(input[pos])) {\n\t\t\t\t\t\t\tpos++\n\t\t\t\t\t\t}\n\t\t\t\t\t\treturn pos < input.length ? input[pos] : \"\"\n\t\t\t\t\t}\n\t\t\t\t\tvar expression = function () {\n\t\t\t\t\t\tvar n = term()\n\t\t\t\t\t\twhile (peek() === \"+\" || peek() === \"-\") {\n\t\t\t\t\t\t\tn = input[pos++] === \"+\" ? n + term() : n - term()\n\t\t\t\t\t\t}\n\t\t\t\t\t\treturn n\n\t\t\t\t\t}\n\t\t\t\t\tvar term = function () {\n\t\t\t\t\t\tvar n = factor()\n\t\t\t\t\t\twhile (peek() === \"*\" || peek() === \"/\") {\n\t\t\t\t\t\t\tif (input[pos++] === \"*\") {\n\t\t\t\t\t\t\t\tn = n * factor()\n\t\t\t\t\t\t\t} else {\n\t\t\t\t\t\t\t\tvar d = factor()\n\t\t\t\t\t\t\t\tif (d === 0) {\n\t\t\t\t\t\t\t\t\tthrow new Error(\"division by zero\")\n\t\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\t\tn = n / d\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t}\n\t\t\t\t\t\treturn n\n\t\t\t\t\t}\n\t\t\t\t\tvar factor = function () {\n\t\t\t\t\t\tvar c = peek()\n\t\t\t\t\t\tif (c === \"-\") {\n\t\t\t\t\t\t\tpos++\n\t\t\t\t\t\t\treturn -factor()\n\t\t\t\t\t\t}\n\t\t\t\t\t\tif (c === \"(\") {\n\t\t\t\t\t\t\tpos++\n\t\t\t\t\t\t\tvar n = expression()\n\t\t\t\t\t\t\tif (peek() !== \")\") {\n\t\t\t\t\t\t\t\tthrow new Error(\"missing ')'\")\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\tpos++\n\t\t\t\t\t\t\treturn n\n\t\t\t\t\t\t}\n\t\t\t\t\t\tif (c === \"{\") {\n\t\t\t\t\t\t\tvar end = input.indexOf(\"}\", pos)\n\t\t\t\t\t\t\tif (end < 0) {\n\t\t\t\t\t\t\t\tthrow new Error(\"missing '}'\")\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\tvar ref = input.slice(pos + 1, end).trim()\n\t\t\t\t\t\t\tpos = end + 1\n\t\t\t\t\t\t\treturn resolve(ref)\n\t\t\t\t\t\t}\n\t\t\t\t\t\tvar number = /^[0-9.]+/.exec(input.slice(pos))\n\t\t\t\t\t\tif (!number || isNaN(parseFloat(number[0]))) {\n\t\t\t\t\t\t\tthrow new Error(\"invalid expression\")\n\t\t\t\t\t\t}\n\t\t\t\t\t\tpos += number[0].length\n\t\t\t\t\t\treturn parseFloat(number[0])\n\t\t\t\t\t}\n\t\t\t\t\tvar result = expression()\n\t\t\t\t\tif (peek() !== \"\") {\n\t\t\t\t\t\tthrow new Error(\"invalid expression\")\n\t\t\t\t\t}\n\t\t\t\t\treturn result\n\t\t\t\t}\n\n\t\t\t\t// initSignaturePad allows respondents to draw signatures on signature fields' canvases. When a stroke ends, the\n\t\t\t\t// signature is written to the field's hidden input as a PNG data URL.\n\t\t\t\tfunction initSignaturePad(pad) {\n\t\t\t\t\tvar canvas = pad.querySelector(\"canvas\")\n\t\t\t\t\tvar input = pad.querySelector(\"input[type=hidden]\")\n\t\t\t\t\tvar ctx = canvas.getContext(\"2d\")\n\t\t\t\t\tvar drawing = false\n\t\t\t\t\tcanvas.width = canvas.offsetWidth\n\t\t\t\t\tcanvas.height = canvas.offsetHeight\n\t\t\t\t\tctx.lineWidth = 2\n\t\t\t\t\tctx.lineCap = \"round\"\n\t\t\t\t\tctx.strokeStyle = \"#1e293b\"\n\n\t\t\t\t\tvar position = function (evt) {\n\t\t\t\t\t\tvar rect = canvas.getBoundingClientRect()\n\t\t\t\t\t\treturn { x: evt.clientX - rect.left, y: evt.clientY - rect.top }\n\t\t\t\t\t}\n\t\t\t\t\tcanvas.addEventListener(\"pointerdown\", function (evt) {\n\t\t\t\t\t\tdrawing = true\n\t\t\t\t\t\tcanvas.setPointerCapture(evt.pointerId)\n\t\t\t\t\t\tvar p = position(evt)\n\t\t\t\t\t\tctx.beginPath()\n\t\t\t\t\t\tctx.moveTo(p.x, p.y)\n\t\t\t\t\t})\n\t\t\t\t\tcanvas.addEventListener(\"pointermove\", function (evt) {\n\t\t\t\t\t\tif (!drawing) {\n\t\t\t\t\t\t\treturn\n\t\t\t\t\t\t}\n\t\t\t\t\t\tvar p = position(evt)\n\t\t\t\t\t\tctx.lineTo(p.x, p.y)\n\t\t\t\t\t\tctx.stroke()\n\t\t\t\t\t})\n\t\t\t\t\tvar end = function () {\n\t\t\t\t\t\tif (!drawing) {\n\t\t\t\t\t\t\treturn\n\t\t\t\t\t\t}\n\t\t\t\t\t\tdrawing = false\n\t\t\t\t\t\tinput.value = canvas.toDataURL(\"image/png\")\n\t\t\t\t\t\tpad.dispatchEvent(new CustomEvent(\"field_change\", {\n\t\t\t\t\t\t\tbubbles: true,\n\t\t\t\t\t\t\tdetail: { field_id: pad.dataset.fieldId, value: input.value }\n\t\t\t\t\t\t}))\n\t\t\t\t\t}\n\t\t\t\t\tcanvas.addEventListener(\"pointerup\", end)\n\t\t\t\t\tcanvas.addEventListener(\"pointercancel\", end)\n\t\t\t\t}\n\n\t\t\t\t// clearSignature erases the signature drawn on a signature field\n\t\t\t\tfunction clearSignature(pad) {\n\t\t\t\t\tvar canvas = pad.querySelector(\"canvas\")\n\t\t\t\t\tcanvas.getContext(\"2d\").clearRect(0, 0, canvas.width, canvas.height)\n\t\t\t\t\tpad.querySelector(\"input[type=hidden]\").value = \"\"\n\t\t\t\t}\n\n\t\t\t\t// maskPhoneNumber removes characters that are not used in phone numbers as respondents type them. A leading\n\t\t\t\t// '+' is allowed for international calling codes.\n\t\t\t\tfunction maskPhoneNumber(input) {\n\t\t\t\t\tvar masked = input.value.replace(/[^0-9 ().+-]/g, '')\n\t\t\t\t\tmasked = masked.charAt(0) + masked.slice(1).replace(/[+]/g, '')\n\t\t\t\t\tif (masked !== input.value) {\n\t\t\t\t\t\tinput.value = masked\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\t// addCrossFieldRule adds a cross-field rule to the builder's field configuration, from the configuration's\n\t\t\t\t// template. New rules' inputs are keyed uniquely, so that they're not confused with existing rules.\n\t\t\t\tfunction addCrossFieldRule(button) {\n\t\t\t\t\tvar configuration = button.closest(\".cross-field-rules-configuration\")\n\t\t\t\t\tvar template = configuration.querySelector(\"template\")\n\t\t\t\t\tvar rules = configuration.querySelector(\".cross-field-rules\")\n\t\t\t\t\trules.insertAdjacentHTML(\"beforeend\", template.innerHTML.replaceAll(\"__new__\", Date.now()))\n\t\t\t\t\t_hyperscript.processNode(rules.lastElementChild)\n\t\t\t\t}\n\n\t\t\t\t// addLogicGroup adds a group of conditions to a field's logic in the builder, starting with a single condition\n\t\t\t\tfunction addLogicGroup(button) {\n\t\t\t\t\tvar configuration = button.closest(\".logic-configuration\")\n\t\t\t\t\tvar template = configuration.querySelector(\":scope > template\")\n\t\t\t\t\tvar groups = configuration.querySelector(\".logic-groups\")\n\t\t\t\t\tgroups.insertAdjacentHTML(\"beforeend\", template.innerHTML.replaceAll(\"__group__\", Date.now()))\n\t\t\t\t\t_hyperscript.processNode(groups.lastElementChild)\n\t\t\t\t\taddLogicCondition(groups.lastElementChild.querySelector(\".add-logic-condition\"))\n\t\t\t\t}\n\n\t\t\t\t// addLogicCondition adds a condition to a group of field logic conditions in the builder\n\t\t\t\tfunction addLogicCondition(button) {\n\t\t\t\t\tvar group = button.closest(\".logic-group\")\n\t\t\t\t\tvar template = group.querySelector(\":scope > template\")\n\t\t\t\t\tvar conditions = group.querySelector(\".logic-conditions\")\n\t\t\t\t\tconditions.insertAdjacentHTML(\"beforeend\", template.innerHTML.replaceAll(\"__condition__\", Date.now()))\n\t\t\t\t\thtmx.process(conditions.lastElementChild)\n\t\t\t\t\t_hyperscript.processNode(conditions.lastElementChild)\n\t\t\t\t}\n\n\t\t\t\t// fields with custom validation messages report them in place of the browser's messages when answers are\n\t\t\t\t// too short, too long, or don't match the field's pattern\n\t\t\t\tdocument.addEventListener(\"invalid\", function (evt) {\n\t\t\t\t\tvar input = evt.target\n\t\t\t\t\tif (!input.dataset || !input.dataset.validationMessage) {\n\t\t\t\t\t\treturn\n\t\t\t\t\t}\n\t\t\t\t\tvar validity = input.validity\n\t\t\t\t\tif (validity.tooShort || validity.tooLong || validity.patternMismatch) {\n\t\t\t\t\t\tinput.setCustomValidity(input.dataset.validationMessage)\n\t\t\t\t\t}\n\t\t\t\t}, true)\n\t\t\t\tdocument.addEventListener(\"input\", function (evt) {\n\t\t\t\t\tif (evt.target.dataset && evt.target.dataset.validationMessage) {\n\t\t\t\t\t\tevt.target.setCustomValidity(\"\")\n\t\t\t\t\t}\n\t\t\t\t}, true)\n\n\t\t\t\t// formValueChanged handles changes to user input in form fields, applying the logic of every field on the form\n\t\t\t\tfunction formValueChanged(form) {\n\t\t\t\t\tvar formMetadata = JSON.parse(document.getElementById('form-metadata').getAttribute(\"data-data\"));\n\t\t\t\t\tif (formMetadata == null) {\n\t\t\t\t\t\treturn\n\t\t\t\t\t}\n\t\t\t\t\tvar fields = formMetadata.form.fields\n\t\t\t\t\t// values set by logic, and answers withdrawn because their options are no longer offered, may change\n\t\t\t\t\t// whether other fields' logic is met and which options they offer, so logic is applied until it changes no\n\t\t\t\t\t// more values\n\t\t\t\t\tfor (let pass = 0; pass <= Object.keys(fields).length; pass++) {\n\t\t\t\t\t\tlet states = evaluateLogic(fields, new FormData(form))\n\t\t\t\t\t\tlet valuesSet = applyLogic(fields, states)\n\t\t\t\t\t\tlet optionsChanged = filterOptions(fields, states, new FormData(form))\n\t\t\t\t\t\tif (!valuesSet && !optionsChanged) {\n\t\t\t\t\t\t\treturn\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\t// optionsOffered records the options offered by fields whose options depend on their options parents' answers, so\n\t\t\t\t// that select inputs' choices are only replaced when the options they offer change\n\t\t\t\tvar optionsOffered = {}\n\n\t\t\t\t// filterOptions offers only the options of fields that are available for their options parents' answers,\n\t\t\t\t// withdrawing answers whose options are no longer offered, and returns whether any options changed\n\t\t\t\tfunction filterOptions(fields, states, data) {\n\t\t\t\t\tvar changed = false\n\t\t\t\t\tfor (let fieldID in fields) {\n\t\t\t\t\t\tlet field = fields[fieldID]\n\t\t\t\t\t\tlet parentID = field.options_parent_id\n\t\t\t\t\t\tif (parentID == null || fields[parentID] == null || field.type === \"ranking\" || states[fieldID].excluded) {\n\t\t\t\t\t\t\tcontinue\n\t\t\t\t\t\t}\n\t\t\t\t\t\tlet answers = states[parentID].excluded ? [] : data.getAll(parentID).filter(v => typeof v === \"string\" && v.trim() !== \"\")\n\t\t\t\t\t\tlet offered = (field.options || []).filter(function(option) {\n\t\t\t\t\t\t\tlet parentValues = option.parent_values || []\n\t\t\t\t\t\t\treturn parentValues.length == 0 || answers.some(answer => parentValues.some(v => v.localeCompare(answer.trim(), 'en', {sensitivity: \"base\"}) == 0))\n\t\t\t\t\t\t}).map(option => option.value)\n\t\t\t\t\t\tlet offeredChanged = optionsOffered[fieldID] !== offered.join(',')\n\t\t\t\t\t\toptionsOffered[fieldID] = offered.join(',')\n\t\t\t\t\t\tchanged = changed || offeredChanged\n\t\t\t\t\t\tfor (let input of document.getElementsByName(fieldID)) {\n\t\t\t\t\t\t\t// logic re-enables the inputs of fields that are not excluded, so choices are disabled on every pass\n\t\t\t\t\t\t\tif (input.type === \"radio\" || input.type === \"checkbox\") {\n\t\t\t\t\t\t\t\tlet available = offered.includes(input.value)\n\t\t\t\t\t\t\t\tchanged = changed || (input.checked && !available)\n\t\t\t\t\t\t\t\tinput.checked = input.checked && available\n\t\t\t\t\t\t\t\tinput.disabled = !available\n\t\t\t\t\t\t\t\tlet label = input.closest(\"label\")\n\t\t\t\t\t\t\t\tif (label != null) {\n\t\t\t\t\t\t\t\t\tlabel.classList.toggle(\"hidden\", !available)\n\t\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\t} else if (input._choices != null && offeredChanged) {\n\t\t\t\t\t\t\t\tlet selected = Array.of(input._choices.getValue(true)).flat().filter(v => offered.includes(v))\n\t\t\t\t\t\t\t\tlet choices = (field.options || []).slice().sort((a, b) => a.order - b.order).map(option => ({\n\t\t\t\t\t\t\t\t\tvalue: option.value,\n\t\t\t\t\t\t\t\t\tlabel: option.label,\n\t\t\t\t\t\t\t\t\tselected: selected.includes(option.value),\n\t\t\t\t\t\t\t\t\tdisabled: !offered.includes(option.value),\n\t\t\t\t\t\t\t\t}))\n\t\t\t\t\t\t\t\tinput._choices.removeActiveItems()\n\t\t\t\t\t\t\t\tinput._choices.setChoices(choices, \"value\", \"label\", true)\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t\treturn changed\n\t\t\t\t}\n\n\t\t\t\t// logicValuesSet records the fields whose values are set by their logic, so that values are set when fields'\n\t\t\t\t// logic becomes met, and respondents may then change them, unless the fields are read-only\n\t\t\t\tvar logicValuesSet = {}\n\n\t\t\t\t// applyLogic applies the evaluated states of fields to the form, returning whether any field's value was set\n\t\t\t\tfunction applyLogic(fields, states) {\n\t\t\t\t\tvar valuesSet = false\n\t\t\t\t\tfor (let fieldID in states) {\n\t\t\t\t\t\tlet field = fields[fieldID]\n\t\t\t\t\t\tlet state = states[fieldID]\n\t\t\t\t\t\tlet el = document.getElementById(`field-container-${fieldID}`)\n\t\t\t\t\t\tif (el == null) {\n\t\t\t\t\t\t\tcontinue\n\t\t\t\t\t\t}\n\t\t\t\t\t\tel.classList.toggle(\"hidden\", state.hidden)\n\t\t\t\t\t\t// excluded fields' inputs are disabled, so that they're neither validated nor submitted, and read-only\n\t\t\t\t\t\t// fields are inert, so that respondents cannot change them\n\t\t\t\t\t\tel.querySelectorAll(\"input, select, textarea\").forEach(input => input.disabled = state.excluded)\n\t\t\t\t\t\tel.toggleAttribute(\"inert\", state.disabled)\n\t\t\t\t\t\tif (field.logic == null) {\n\t\t\t\t\t\t\tcontinue\n\t\t\t\t\t\t}\n\n\t\t\t\t\t\t// radio form elements such as \"single choice\" elements cannot get gotten by ID because they are\n\t\t\t\t\t\t// radio button in a form group, all sharing a \"name\" attribute, rather than having one unique id\n\t\t\t\t\t\t// like other input elements. Thus, when we cannot get a field by ID, we must be able to get it by\n\t\t\t\t\t\t// name.\n\t\t\t\t\t\tlet fieldElement = document.getElementById(fieldID) || document.getElementsByName(fieldID)[0]\n\t\t\t\t\t\tif (fieldElement != null && state.required) {\n\t\t\t\t\t\t\tfieldElement.setAttribute(\"required\", \"\")\n\t\t\t\t\t\t} else if (fieldElement != null) {\n\t\t\t\t\t\t\tfieldElement.removeAttribute(\"required\")\n\t\t\t\t\t\t}\n\t\t\t\t\t\tlet endMessage = document.getElementById(`logic-end-message-${fieldID}`)\n\t\t\t\t\t\tif (endMessage != null) {\n\t\t\t\t\t\t\tendMessage.classList.toggle(\"hidden\", !state.ends)\n\t\t\t\t\t\t}\n\t\t\t\t\t\tif (state.value == null) {\n\t\t\t\t\t\t\tdelete logicValuesSet[fieldID]\n\t\t\t\t\t\t\tcontinue\n\t\t\t\t\t\t}\n\t\t\t\t\t\tif (logicValuesSet[fieldID] && !state.disabled) {\n\t\t\t\t\t\t\tcontinue\n\t\t\t\t\t\t}\n\t\t\t\t\t\tlogicValuesSet[fieldID] = true\n\t\t\t\t\t\tvaluesSet = setFieldValue(fieldID, state.value) || valuesSet\n\t\t\t\t\t}\n\t\t\t\t\treturn valuesSet\n\t\t\t\t}\n\n\t\t\t\t// setFieldValue sets the value of a field's inputs, returning whether the value changed\n\t\t\t\tfunction setFieldValue(fieldID, values) {\n\t\t\t\t\tvar changed = false\n\t\t\t\t\t// sliders are unnamed until they're answered\n\t\t\t\t\tfor (let input of document.querySelectorAll(`[name=\"${fieldID}\"], input[type=range][data-name=\"${fieldID}\"]`)) {\n\t\t\t\t\t\tif (input.type === \"range\") {\n\t\t\t\t\t\t\tchanged = changed || input.name !== fieldID || input.value !== values.join(',')\n\t\t\t\t\t\t\tinput.name = fieldID\n\t\t\t\t\t\t\tinput.value = values.join(',')\n\t\t\t\t\t\t\tdocument.getElementById(`slider-value-${fieldID}`).value = input.value\n\t\t\t\t\t\t} else if (input.type === \"radio\" || input.type === \"checkbox\") {\n\t\t\t\t\t\t\tlet checked = values.includes(input.value)\n\t\t\t\t\t\t\tchanged = changed || input.checked != checked\n\t\t\t\t\t\t\tinput.checked = checked\n\t\t\t\t\t\t} else if (input._choices != null) {\n\t\t\t\t\t\t\tif (Array.of(input._choices.getValue(true)).flat().join(',') !== values.join(',')) {\n\t\t\t\t\t\t\t\tinput._choices.removeActiveItems()\n\t\t\t\t\t\t\t\tinput._choices.setChoiceByValue(values)\n\t\t\t\t\t\t\t\tchanged = true\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t} else if (input.type !== \"hidden\" && input.value !== values.join(',')) {\n\t\t\t\t\t\t\tinput.value = values.join(',')\n\t\t\t\t\t\t\tchanged = true\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t\treturn changed\n\t\t\t\t}\n\n\t\t\t\t// evaluateLogic computes the state of every field from the form's data, mirroring the server's evaluation of\n\t\t\t\t// field logic: fields whose logic shows or requires them are hidden and excluded until their logic's groups\n\t\t\t\t// of conditions are met, the other actions of fields' logic take effect when their conditions are met, and\n\t\t\t\t// conditions that target excluded fields are evaluated as if the target had no value\n\t\t\t\tfunction evaluateLogic(fields, data) {\n\t\t\t\t\tvar states = {}\n\t\t\t\t\tvar evaluating = {}\n\t\t\t\t\tvar evaluate = function(fieldID) {\n\t\t\t\t\t\tif (states[fieldID] != null) {\n\t\t\t\t\t\t\treturn states[fieldID]\n\t\t\t\t\t\t}\n\t\t\t\t\t\tlet field = fields[fieldID]\n\t\t\t\t\t\tlet state = {hidden: field.hidden, excluded: false, required: field.required, disabled: false, value: null, ends: false}\n\t\t\t\t\t\t// fields being evaluated are in logic cycles, and take their static state while their cycle is evaluated\n\t\t\t\t\t\tif (evaluating[fieldID]) {\n\t\t\t\t\t\t\treturn state\n\t\t\t\t\t\t}\n\t\t\t\t\t\tlet logic = field.logic\n\t\t\t\t\t\tif (logic == null) {\n\t\t\t\t\t\t\tstates[fieldID] = state\n\t\t\t\t\t\t\treturn state\n\t\t\t\t\t\t}\n\n\t\t\t\t\t\tevaluating[fieldID] = true\n\t\t\t\t\t\tlet groups = logic.groups.map(function(group) {\n\t\t\t\t\t\t\tlet conditions = group.conditions.map(function(condition) {\n\t\t\t\t\t\t\t\tlet target = fields[condition.target_field_id]\n\t\t\t\t\t\t\t\tlet values = []\n\t\t\t\t\t\t\t\tif (target != null && !evaluate(condition.target_field_id).excluded) {\n\t\t\t\t\t\t\t\t\tvalues = data.getAll(condition.target_field_id)\n\t\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\t\treturn conditionMatches(condition, target, values)\n\t\t\t\t\t\t\t})\n\t\t\t\t\t\t\treturn combineLogic(group.operator, conditions)\n\t\t\t\t\t\t})\n\t\t\t\t\t\tdelete evaluating[fieldID]\n\n\t\t\t\t\t\tstate = applyActions(logic, combineLogic(logic.operator, groups), state)\n\t\t\t\t\t\tstates[fieldID] = state\n\t\t\t\t\t\treturn state\n\t\t\t\t\t}\n\t\t\t\t\tfor (let fieldID in fields) {\n\t\t\t\t\t\tevaluate(fieldID)\n\t\t\t\t\t}\n\n\t\t\t\t\t// when logic ends the form, the fields after the earliest field whose logic ends it are excluded\n\t\t\t\t\tvar end = null\n\t\t\t\t\tfor (let fieldID in fields) {\n\t\t\t\t\t\tif (states[fieldID].ends && (end == null || fields[fieldID].order < end.order)) {\n\t\t\t\t\t\t\tend = fields[fieldID]\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t\tfor (let fieldID in fields) {\n\t\t\t\t\t\tif (end != null && fields[fieldID].order > end.order) {\n\t\t\t\t\t\t\tstates[fieldID] = {hidden: true, excluded: true, required: false, disabled: false, value: null, ends: false}\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t\treturn states\n\t\t\t\t}\n\n\t\t\t\t// applyActions applies the actions of a field's logic to the field's state, given whether its logic is met\n\t\t\t\tfunction applyActions(logic, met, state) {\n\t\t\t\t\tlet actions = logic.actions || []\n\t\t\t\t\tif (actions.includes(\"field_logic_trigger_show\") || actions.includes(\"field_logic_trigger_require\")) {\n\t\t\t\t\t\tif (!met) {\n\t\t\t\t\t\t\treturn {hidden: true, excluded: true, required: false, disabled: false, value: null, ends: false}\n\t\t\t\t\t\t}\n\t\t\t\t\t\tstate.hidden = false\n\t\t\t\t\t\tstate.required = state.required || actions.includes(\"field_logic_trigger_require\")\n\t\t\t\t\t}\n\t\t\t\t\tif (!met) {\n\t\t\t\t\t\treturn state\n\t\t\t\t\t}\n\t\t\t\t\tif (actions.includes(\"field_logic_trigger_hide\")) {\n\t\t\t\t\t\tstate = {hidden: true, excluded: true, required: false, disabled: false, value: null, ends: false}\n\t\t\t\t\t}\n\t\t\t\t\tif (actions.includes(\"field_logic_trigger_disable\")) {\n\t\t\t\t\t\tstate.disabled = true\n\t\t\t\t\t\tstate.required = false\n\t\t\t\t\t}\n\t\t\t\t\tif (actions.includes(\"field_logic_trigger_set_value\")) {\n\t\t\t\t\t\tstate.value = [logic.value || \"\"]\n\t\t\t\t\t}\n\t\t\t\t\tstate.ends = actions.includes(\"field_logic_trigger_end_form\")\n\t\t\t\t\treturn state\n\t\t\t\t}\n\n\t\t\t\t// logicValues returns the values of a field that logic conditions compare with their values. Consent fields are\n\t\t\t\t// submitted alongside a hidden \"false\" input, so they're compared by whether they're ticked.\n\t\t\t\tfunction logicValues(field, values) {\n\t\t\t\t\tvalues = values.filter(value => typeof value === \"string\")\n\t\t\t\t\tif (field != null && field.type === \"consent\" && values.length > 0) {\n\t\t\t\t\t\treturn [values.includes(\"true\").toString()]\n\t\t\t\t\t}\n\t\t\t\t\treturn values\n\t\t\t\t}\n\n\t\t\t\t// logicNumber returns the number answered to a field with numeric answers, or null when there is none. Choice\n\t\t\t\t// fields' answers are the numbers in the labels of their chosen options.\n\t\t\t\tfunction logicNumber(field, values) {\n\t\t\t\t\tvar parse = function(value) {\n\t\t\t\t\t\tlet n = Number(String(value).trim())\n\t\t\t\t\t\treturn String(value).trim() !== \"\" && isFinite(n) ? n : null\n\t\t\t\t\t}\n\t\t\t\t\tif (field == null) {\n\t\t\t\t\t\treturn null\n\t\t\t\t\t}\n\t\t\t\t\tlet numeric = [\"number\", \"nps\", \"star_rating\", \"calculated\", \"slider\"].includes(field.type)\n\t\t\t\t\tlet choice = [\"single_select\", \"multi_select\", \"single_choice\", \"single_choice_spaced\", \"checkbox_group\", \"ranking\"].includes(field.type)\n\t\t\t\t\tfor (let value of values) {\n\t\t\t\t\t\tif (numeric && parse(value) != null) {\n\t\t\t\t\t\t\treturn parse(value)\n\t\t\t\t\t\t}\n\t\t\t\t\t\tif (!choice || field.data_type === \"text\") {\n\t\t\t\t\t\t\tcontinue\n\t\t\t\t\t\t}\n\t\t\t\t\t\tlet option = (field.options || []).find(option => option.value === value || option.id === value)\n\t\t\t\t\t\tif (option != null && parse(option.label) != null) {\n\t\t\t\t\t\t\treturn parse(option.label)\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t\treturn null\n\t\t\t\t}\n\n\t\t\t\t// conditionMatches returns whether a field logic condition is met by its target field's values\n\t\t\t\tfunction conditionMatches(condition, target, values) {\n\t\t\t\t\tvalues = logicValues(target, values)\n\t\t\t\t\tlet value = values.join(',')\n\t\t\t\t\tlet answered = values.map(v => v.trim()).filter(v => v !== \"\")\n\t\t\t\t\tlet n = logicNumber(target, values)\n\t\t\t\t\tlet bounds = (condition.values || []).map(Number)\n\t\t\t\t\tswitch (condition.comparator) {\n\t\t\t\t\t\tcase 'equal':\n\t\t\t\t\t\t\treturn condition.values.every(val => value.localeCompare(val, 'en', {sensitivity: \"base\"}) == 0)\n\t\t\t\t\t\tcase 'contains':\n\t\t\t\t\t\t\treturn condition.values.some(val => value.toLowerCase().includes(val.toLowerCase()))\n\t\t\t\t\t\tcase 'not':\n\t\t\t\t\t\t\treturn condition.values.some(val => value.toLowerCase() !== val.toLowerCase())\n\t\t\t\t\t\tcase 'is_empty':\n\t\t\t\t\t\t\treturn answered.length == 0\n\t\t\t\t\t\tcase 'is_not_empty':\n\t\t\t\t\t\t\treturn answered.length > 0\n\t\t\t\t\t\tcase 'greater_than':\n\t\t\t\t\t\t\treturn n != null && bounds.length > 0 && n > bounds[0]\n\t\t\t\t\t\tcase 'less_than':\n\t\t\t\t\t\t\treturn n != null && bounds.length > 0 && n < bounds[0]\n\t\t\t\t\t\tcase 'between':\n\t\t\t\t\t\t\treturn n != null && bounds.length > 1 && n >= Math.min(bounds[0], bounds[1]) && n <= Math.max(bounds[0], bounds[1])\n\t\t\t\t\t}\n\t\t\t\t\treturn false\n\t\t\t\t}\n\n\t\t\t\t// combineLogic combines the results of conditions, or groups of conditions, with a field logic operator\n\t\t\t\tfunction combineLogic(operator, results) {\n\t\t\t\t\tif (operator === \"or\") {\n\t\t\t\t\t\treturn results.includes(true)\n\t\t\t\t\t}\n\t\t\t\t\treturn !results.includes(false)\n\t\t\t\t}\n\t\t\t</script>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			return nil
 		})
-		templ_7745c5c3_Err = loadDependenciesOnce.Once().Render(templ.WithChildren(ctx, templ_7745c5c3_Var39), templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = loadDependenciesOnce.Once().Render(templ.WithChildren(ctx, templ_7745c5c3_Var40), templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 77, "</head>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 79, "</head>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -1109,9 +1129,9 @@ func footer() templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var55 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var55 == nil {
-			templ_7745c5c3_Var55 = templ.NopComponent
+		templ_7745c5c3_Var56 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var56 == nil {
+			templ_7745c5c3_Var56 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
 		return nil
@@ -1135,12 +1155,12 @@ func App(pageTitle string) templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var56 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var56 == nil {
-			templ_7745c5c3_Var56 = templ.NopComponent
+		templ_7745c5c3_Var57 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var57 == nil {
+			templ_7745c5c3_Var57 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 78, "<!doctype html><html lang=\"eng\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 80, "<!doctype html><html lang=\"eng\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -1148,15 +1168,15 @@ func App(pageTitle string) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 79, "<body><div id=\"messages\"></div><main>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 81, "<body><div id=\"messages\"></div><main>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templ_7745c5c3_Var56.Render(ctx, templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = templ_7745c5c3_Var57.Render(ctx, templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 80, "</main>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 82, "</main>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -1164,7 +1184,7 @@ func App(pageTitle string) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 81, "</body></html>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 83, "</body></html>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -1182,15 +1202,23 @@ func FormUrl[T string | templ.SafeURL](ctx context.Context, form frm.Form, path
 }
 
 // FieldOptionsAsSelectorOptions returns all of a field's FieldOptions as selector.Options
+//
+// Options are selected when they're the values of logic conditions that target the field.
+//
+// Deprecated: The builder configures each logic condition's values individually; use fields.ToSelectorOpts.
 func FieldOptionsAsSelectorOptions(form frm.Form, field types.FormField) (options []selector.Option) {
 	for _, option := range field.Options {
 		selected := false
 		for _, f := range form.Fields {
-			if f.Logic == nil || f.Logic.TriggerValues == nil || len(f.Logic.TriggerValues) == 0 {
+			if f.Logic == nil {
 				continue
 			}
-			if f.Logic != nil && slices.Contains(f.Logic.TriggerValues, option.ID.String()) {
-				selected = true
+			for _, group := range f.Logic.ConditionGroups() {
+				for _, condition := range group.Conditions {
+					if condition.TargetFieldID == field.ID && slices.Contains(condition.Values, option.ID.String()) {
+						selected = true
+					}
+				}
 			}
 		}
 		options = append(options, selector.Option{
@@ -1220,40 +1248,40 @@ func ValidationErrors(errs types.ValidationErrors) templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var57 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var57 == nil {
-			templ_7745c5c3_Var57 = templ.NopComponent
+		templ_7745c5c3_Var58 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var58 == nil {
+			templ_7745c5c3_Var58 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
 		if len(errs) > 0 {
 			for fieldID, err := range errs {
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 82, "<div id=\"")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 84, "<div id=\"")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				var templ_7745c5c3_Var58 string
-				templ_7745c5c3_Var58, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("errors-%s", fieldID))
+				var templ_7745c5c3_Var59 string
+				templ_7745c5c3_Var59, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("errors-%s", fieldID))
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 423, Col: 46}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 931, Col: 46}
 				}
-				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var58))
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var59))
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 83, "\" data-hx-swap-oob=\"true\" class=\"flex flex-col gap-3 py-3\"><p class=\"text-red-500\">")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 85, "\" data-hx-swap-oob=\"true\" class=\"flex flex-col gap-3 py-3\"><p class=\"text-red-500\">")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				var templ_7745c5c3_Var59 string
-				templ_7745c5c3_Var59, templ_7745c5c3_Err = templ.JoinStringErrs(err.Error())
+				var templ_7745c5c3_Var60 string
+				templ_7745c5c3_Var60, templ_7745c5c3_Err = templ.JoinStringErrs(err.Error())
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 425, Col: 18}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/common.templ`, Line: 933, Col: 18}
 				}
-				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var59))
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var60))
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 84, "</p></div>")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 86, "</p></div>")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}